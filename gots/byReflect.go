@@ -5,6 +5,7 @@ import (
 	"io"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -13,78 +14,118 @@ import (
 // Can wrap types in given namespace (if empty will omit namespace).
 //
 // If pkg is specified it will generate types in packages containing given prefix.
+//
+// Use Generator for more options.
 func GenerateTypeDefinition(out io.StringWriter, namespace string, pkg string, typesToGenerate ...any) error {
-	generator := definitionGenerator{
-		out:       out,
-		namespace: namespace,
-		pkg:       pkg,
-	}
-	return generator.Generate(typesToGenerate...)
+	return NewGenerator(WithNamespace(namespace), WithPackages(pkg)).
+		Add(typesToGenerate...).
+		Generate(out)
 }
 
-type definitionGenerator struct {
-	out       io.StringWriter
-	indent    int
-	namespace string
-	pkg       string
+// Enum registers known values of type (constants). Type will be written as union of values.
+//
+// Pass result with other types to generate, eg. gots.GenerateTypeDefinition(out, "", pkg, Model{}, gots.Enum(StatusActive, StatusDisabled)).
+func Enum(values ...any) any {
+	return enumValues{values}
 }
 
-func (g *definitionGenerator) Generate(o ...any) error {
-	if g.namespace != "" {
-		g.outLine("declare namespace " + g.namespace + " {")
-		g.doIndent()
-	}
-	if err := g.writeDefinition(o...); err != nil {
-		return err
-	}
-
-	if g.namespace != "" {
-		g.outLine("}")
-		g.doDeIndent()
-	}
-	return nil
+type enumValues struct {
+	values []any
 }
 
-func (g *definitionGenerator) outLine(v string) {
-	g.out.WriteString(strings.Repeat("  ", g.indent))
-	g.out.WriteString(v)
-	g.out.WriteString("\n")
-}
+type definitionGenerator struct {
+	*options
 
-func (g *definitionGenerator) doIndent() {
-	g.indent++
-}
+	// known values of types by type ID
+	values map[string][]*tsValue
 
-func (g *definitionGenerator) doDeIndent() {
-	g.indent--
-}
+	// instances of generic types by type ID
+	genericInstances map[string][]reflect.Type
+	decls            []*tsDecl
+
+	// views registered with View
+	views []viewModel
+	// unions registered with Union by interface type ID
+	unions map[string]unionTypes
+	// discriminators of union implementations by type ID
+	discriminators map[string][]discriminatorValue
 
-func (g *definitionGenerator) outNext(v string) {
-	g.out.WriteString(v)
+	// globals by name in runtime, see Generator.GenerateGlobals
+	globals     map[string]any
+	globalDecls []*tsDecl
+
+	diagnostics
 }
 
-func (g *definitionGenerator) outEndLine() {
-	g.out.WriteString("\n")
+func (g *definitionGenerator) Generate(o ...any) ([]*tsDecl, error) {
+	if err := g.writeDefinition(o...); err != nil {
+		return nil, err
+	}
+	if err := g.diagnosticsError(g.list); err != nil {
+		return nil, err
+	}
+	return g.decls, nil
 }
 
 func (g *definitionGenerator) shouldWriteType(t reflect.Type, i exTypeInfo) bool {
 	if i.IsBasicType {
 		return false
 	}
-	if !strings.HasPrefix(t.PkgPath(), g.pkg) {
+	if !g.includesPackage(t.PkgPath()) {
+		return false
+	}
+
+	// unnamed and predeclared (error) types
+	if t.Name() == "" || t.PkgPath() == "" {
+		return false
+	}
+
+	if isPlaceholderType(t) {
+		return false
+	}
+
+	if g.excluded[getTypeID(t)] {
 		return false
 	}
 
-	if t.Name() == "" {
+	if _, isMapped := g.getMappedType(t); isMapped {
 		return false
 	}
 
 	return true
 }
 
+// Returns typescript type if type is mapped by type mapping.
+//
+// Types mapped by name are always mapped, types with known values are not mapped as marshalers.
+func (g *definitionGenerator) getMappedType(t reflect.Type) (string, bool) {
+	if t.Name() != "" {
+		if tsType, ok := g.mapping.lookupName(getTypeID(t)); ok {
+			return tsType, true
+		}
+	}
+	if len(g.values[getTypeID(t)]) > 0 {
+		return "", false
+	}
+	return g.mapping.lookup(t, g.profile.Marshalers)
+}
+
 func (g *definitionGenerator) writeDefinition(o ...any) error {
+	o = g.collectViews(o...)
+	o, err := g.collectUnions(o...)
+	if err != nil {
+		return err
+	}
+	o = g.collectValues(o...)
+	g.collectGenericInstances(o...)
+
 	typesToProcess := []reflect.Type{}
 	processedTypes := map[string]exTypeInfo{}
+	for _, obj := range o {
+		t := getUnderlyingType(reflect.TypeOf(obj))
+		g.root(getTypeID(t), getTypeInfo(t).BaseType)
+	}
+	rootInstances := map[string][]*tsType{}
 	for _, obj := range o {
 		t := reflect.TypeOf(obj)
 		if t.Kind() == reflect.Pointer {
@@ -92,15 +133,33 @@ func (g *definitionGenerator) writeDefinition(o ...any) error {
 		}
 		typeInfo := getTypeInfo(t)
 
-		if _, ok := processedTypes[typeInfo.FullBaseTypeName]; ok {
+		if g.excluded[getTypeID(t)] {
+			continue
+		}
+		if typeInfo.IsGenericType && g.includesPackage(t.PkgPath()) {
+			rootInstances[getTypeID(t)] = append(rootInstances[getTypeID(t)], g.getTypingNameForGenericInstance(t, typeInfo))
+		}
+		if _, ok := processedTypes[getTypeID(t)]; ok {
+			continue
+		}
+		if _, isMapped := g.getMappedType(t); isMapped {
 			continue
 		}
 
-		processedTypes[typeInfo.FullBaseTypeName] = typeInfo
+		processedTypes[getTypeID(t)] = typeInfo
 		useTypes := g.writeType(t, typeInfo)
-		g.outEndLine()
 		typesToProcess = append(typesToProcess, useTypes...)
 	}
+	for _, d := range g.decls {
+		d.Root = true
+		d.Instances = rootInstances[d.ID]
+	}
+	globalTypes, err := g.writeGlobals()
+	if err != nil {
+		return err
+	}
+	typesToProcess = append(typesToProcess, globalTypes...)
+	typesToProcess = append(typesToProcess, g.getViewTypes()...)
 
 	for len(typesToProcess) > 0 {
 		t := typesToProcess[0]
@@ -110,21 +169,187 @@ func (g *definitionGenerator) writeDefinition(o ...any) error {
 		typeInfo := getTypeInfo(t)
 
 		typesToProcess = typesToProcess[1:]
-		if _, ok := processedTypes[typeInfo.FullBaseTypeName]; ok {
+		if _, ok := processedTypes[getTypeID(t)]; ok {
 			continue
 		}
 
-		processedTypes[typeInfo.FullBaseTypeName] = typeInfo
+		processedTypes[getTypeID(t)] = typeInfo
 		if !g.shouldWriteType(t, typeInfo) {
 			continue
 		}
 		useTypes := g.writeType(t, typeInfo)
-		g.outEndLine()
 		typesToProcess = append(typesToProcess, useTypes...)
 	}
+	return g.writeViews()
+}
+
+// Collects views registered with View, returns types to generate (with models of views).
+func (g *definitionGenerator) collectViews(o ...any) []any {
+	result := []any{}
+	for _, obj := range o {
+		view, ok := obj.(viewModel)
+		if !ok {
+			result = append(result, obj)
+			continue
+		}
+		g.views = append(g.views, view)
+		if view.model != nil && getUnderlyingType(reflect.TypeOf(view.model)).Name() != "" {
+			result = append(result, view.model)
+		}
+	}
+	return result
+}
+
+// Returns types used by models of views which are not named (eg. []Contact), named models are registered as types to generate.
+func (g *definitionGenerator) getViewTypes() []reflect.Type {
+	result := []reflect.Type{}
+	for _, v := range g.views {
+		if v.model != nil && getUnderlyingType(reflect.TypeOf(v.model)).Name() == "" {
+			result = append(result, getUsedTypes(reflect.TypeOf(v.model))...)
+		}
+	}
+	return result
+}
+
+// Writes declarations of registered views.
+func (g *definitionGenerator) writeViews() error {
+	written := map[string]bool{}
+	for _, d := range g.decls {
+		written[d.ID] = true
+	}
+	views := []tsView{}
+	for _, v := range g.views {
+		model := tsKeywordType("undefined")
+		if v.model != nil {
+			t := getUnderlyingType(reflect.TypeOf(v.model))
+			g.path = "view " + v.name
+			if written[getTypeID(t)] {
+				model = tsRefType(getTypeID(t), t.Name())
+			} else {
+				model = g.getTypingName(t)
+			}
+		}
+		views = append(views, tsView{Name: v.name, Model: model})
+	}
+	decls, err := getViewDecls(views)
+	if err != nil {
+		return err
+	}
+	g.decls = append(g.decls, decls...)
 	return nil
 }
 
+// Collects unions registered with Union, returns types to generate (with interfaces of unions).
+func (g *definitionGenerator) collectUnions(o ...any) ([]any, error) {
+	g.unions = map[string]unionTypes{}
+	g.discriminators = map[string][]discriminatorValue{}
+	result := []any{}
+	for _, obj := range o {
+		union, ok := obj.(unionTypes)
+		if !ok {
+			result = append(result, obj)
+			continue
+		}
+		if union.iface.Kind() != reflect.Interface {
+			return nil, fmt.Errorf("union %s is not an interface", union.iface)
+		}
+		for _, v := range union.variants {
+			if v.impl == nil {
+				return nil, fmt.Errorf("union %s: variant %v has no implementation", union.iface, v.value)
+			}
+			impl := getUnderlyingType(reflect.TypeOf(v.impl))
+			if !impl.Implements(union.iface) && !reflect.PointerTo(impl).Implements(union.iface) {
+				return nil, fmt.Errorf("union %s: %s doesn't implement it", union.iface, impl)
+			}
+			id := getTypeID(impl)
+			g.discriminators[id] = append(g.discriminators[id], discriminatorValue{
+				Name:  union.discriminator,
+				Value: getReflectValueLiteral(reflect.ValueOf(v.value)),
+			})
+		}
+		g.unions[getTypeID(union.iface)] = union
+		result = append(result, reflect.New(union.iface).Interface())
+	}
+	return result, nil
+}
+
+// Collects values registered with Enum, returns types to generate.
+func (g *definitionGenerator) collectValues(o ...any) []any {
+	g.values = map[string][]*tsValue{}
+	result := []any{}
+	for _, obj := range o {
+		enum, ok := obj.(enumValues)
+		if !ok {
+			result = append(result, obj)
+			continue
+		}
+		for _, v := range enum.values {
+			id := getTypeID(reflect.TypeOf(v))
+			g.values[id] = append(g.values[id], &tsValue{Value: getReflectValueLiteral(reflect.ValueOf(v))})
+		}
+		if len(enum.values) > 0 {
+			result = append(result, enum.values[0])
+		}
+	}
+	return result
+}
+
+// Collects instances of generic types reachable from given types.
+func (g *definitionGenerator) collectGenericInstances(o ...any) {
+	g.genericInstances = map[string][]reflect.Type{}
+	visited := map[reflect.Type]bool{}
+
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		if t.Name() != "" && !g.includesPackage(t.PkgPath()) {
+			return
+		}
+
+		if tInfo := getTypeInfo(t); tInfo.IsGenericType {
+			g.genericInstances[getTypeID(t)] = append(g.genericInstances[getTypeID(t)], t)
+		}
+
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Chan:
+			visit(t.Elem())
+		case reflect.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				if t.Field(i).IsExported() {
+					visit(t.Field(i).Type)
+				}
+			}
+		case reflect.Func:
+			for i := 0; i < t.NumIn(); i++ {
+				visit(t.In(i))
+			}
+			for i := 0; i < t.NumOut(); i++ {
+				visit(t.Out(i))
+			}
+		}
+
+		if t.Name() != "" {
+			methodsOf := t
+			if t.Kind() != reflect.Interface {
+				methodsOf = reflect.PointerTo(t)
+			}
+			for i := 0; i < methodsOf.NumMethod(); i++ {
+				visit(methodsOf.Method(i).Type)
+			}
+		}
+	}
+
+	for _, obj := range o {
+		visit(getUnderlyingType(reflect.TypeOf(obj)))
+	}
+}
+
 type exTypeInfo struct {
 	IsGenericType        bool
 	IsBasicType          bool
@@ -132,17 +357,17 @@ type exTypeInfo struct {
 	IsInterface          bool
 	UnderlyingSystemType reflect.Kind
 	BaseType             string
-	FullBaseTypeName     string
-	TypeParam            string
+	TypeParams           []string
+	Generic              genericParams
 }
 
-var r = regexp.MustCompile(`(([A-Za-z0-9_]*\.)?([A-Za-z0-9_]+))(?:\[((.*\.)?(.*))\])?`)
+var r = regexp.MustCompile(`(([A-Za-z0-9_]*\.)?([A-Za-z0-9_]+))(?:\[(.*)\])?`)
 
 func getTypeInfo(t reflect.Type) exTypeInfo {
 	tname := t.String()
 	match := r.FindStringSubmatch(tname)
 
-	if len(match) < 4 || match[4] == "" {
+	if len(match) < 4 || match[4] == "" || t.Name() == "" {
 		if getUnderlyingType(t).Kind() == reflect.Interface {
 			i := getUnderlyingType(t)
 			return exTypeInfo{
@@ -153,7 +378,6 @@ func getTypeInfo(t reflect.Type) exTypeInfo {
 
 				UnderlyingSystemType: i.Kind(),
 				BaseType:             i.Name(),
-				FullBaseTypeName:     i.String(),
 			}
 		} else {
 			return exTypeInfo{
@@ -163,7 +387,6 @@ func getTypeInfo(t reflect.Type) exTypeInfo {
 
 				UnderlyingSystemType: t.Kind(),
 				BaseType:             match[3],
-				FullBaseTypeName:     tname,
 			}
 		}
 	}
@@ -172,87 +395,156 @@ func getTypeInfo(t reflect.Type) exTypeInfo {
 		IsGenericType:        true,
 		UnderlyingSystemType: t.Kind(),
 		BaseType:             match[3],
-		TypeParam:            match[6],
-		FullBaseTypeName:     match[1],
+		TypeParams:           splitTypeArgs(match[4]),
+	}
+}
+
+// Returns ID of declaration for given type (package path and name without type arguments).
+func getTypeID(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
 	}
+	return t.PkgPath() + "." + name
 }
 
 func (g *definitionGenerator) writeType(t reflect.Type, tInfo exTypeInfo) []reflect.Type {
-	typeName := tInfo.BaseType
+	decl := &tsDecl{
+		ID:      getTypeID(t),
+		PkgPath: t.PkgPath(),
+		Name:    tInfo.BaseType,
+	}
+	g.start(decl.ID, decl.Name)
 
 	if tInfo.IsGenericType {
-		g.outLine(fmt.Sprintf("type %s<T> = {", typeName))
+		tInfo.Generic = getGenericParams(t, tInfo, g.genericInstances[getTypeID(t)])
+		decl.TypeParams = tInfo.Generic.Names
+		if t.Kind() == reflect.Struct {
+			for _, name := range tInfo.Generic.unusedParams() {
+				g.report("type parameter %s is not used by fields (mark field with waxGeneric tag)", name)
+			}
+		}
 	} else if isBaseType(t) {
-		// type alias is object in goja
-		g.outLine(fmt.Sprintf("type %s = %s & { ", typeName, "object"))
-	} else {
-		g.outLine(fmt.Sprintf("type %s = {", typeName))
+		if g.aliasIsObject() {
+			decl.IsAliasObject = true
+		} else {
+			decl.Type = tsKeywordType(g.getTypingNameForBase(t.Kind()))
+		}
+	}
+	decl.Values = g.values[decl.ID]
+	g.decls = append(g.decls, decl)
+	if union, ok := g.unions[decl.ID]; ok {
+		return g.writeUnion(decl, union)
 	}
-	g.doIndent()
-	result := g.writeMembers(t, tInfo)
 
-	g.doDeIndent()
+	result := g.writeMembers(t, tInfo)
+	decl.Members = result.members
+	setDiscriminators(decl, g.discriminators[decl.ID])
 
-	g.outNext("}")
+	decl.Extends = append(decl.Extends, result.extends...)
+	return result.usedTypes
+}
 
-	for _, ao := range result.andAlso {
-		oTI := getTypeInfo(ao)
-		if oTI.IsGenericType {
-			g.outNext(" & " + oTI.BaseType + "<" + oTI.TypeParam + ">")
-		} else {
-			g.outNext(" & " + ao.Name())
-		}
+// Writes interface as union of registered implementations, returns implementations.
+func (g *definitionGenerator) writeUnion(decl *tsDecl, union unionTypes) []reflect.Type {
+	variants := []*tsVariant{}
+	usedTypes := []reflect.Type{}
+	for _, v := range union.variants {
+		impl := getUnderlyingType(reflect.TypeOf(v.impl))
+		variants = append(variants, &tsVariant{
+			Value: getReflectValueLiteral(reflect.ValueOf(v.value)),
+			Type:  g.getTypingName(impl),
+		})
+		usedTypes = append(usedTypes, impl)
 	}
-	g.outEndLine()
-	return result.usedTypes
+	setUnion(decl, union.discriminator, variants)
+	return usedTypes
 }
 
-func (g *definitionGenerator) writeMembers(t reflect.Type, tInfo exTypeInfo) struct {
+type membersResult struct {
+	members []*tsMember
+	// embedded types with promoted fields and their typescript types
 	andAlso   []reflect.Type
+	extends   []*tsType
 	usedTypes []reflect.Type
-} {
+}
+
+func (g *definitionGenerator) writeMembers(t reflect.Type, tInfo exTypeInfo) membersResult {
+	members := []*tsMember{}
 	usedTypes := []reflect.Type{}
 	andAlso := []reflect.Type{}
+	extends := []*tsType{}
 	{
 		if t.Kind() == reflect.Struct {
 			for i := 0; i < t.NumField(); i++ {
 				dumpMemberType := true
 				fieldInfo := t.Field(i)
-				if !fieldInfo.IsExported() {
+				// fields of unexported embedded struct are promoted (as encoding/json and goja do)
+				if !fieldInfo.IsExported() && !(fieldInfo.Anonymous && fieldInfo.Type.Kind() == reflect.Struct) {
+					continue
+				}
+				jsonInfo := g.mapFieldInfo(t, fieldInfo, getJSONFieldInfo(fieldInfo))
+				if jsonInfo.Skip {
 					continue
 				}
+				member := &tsMember{
+					Name:     jsonInfo.name(),
+					Optional: jsonInfo.Optional,
+				}
+				leave := g.enter("." + fieldInfo.Name)
 
 				ft := getUnderlyingType(fieldInfo.Type)
-				if fieldInfo.Anonymous {
+				if fieldInfo.Anonymous && (jsonInfo.Name == "" || jsonInfo.Promoted) {
 					andAlso = append(andAlso, ft)
-				} else {
 					if tInfo.IsGenericType {
-						g.outLine(fmt.Sprintf("%s: %s", fieldInfo.Name, g.getTypingNameForGeneric(fieldInfo)))
+						extends = append(extends, g.getTypingNameForGenericEmbedded(t, ft, i, tInfo.Generic))
+					} else {
+						extends = append(extends, g.getTypingNameForEmbedded(ft))
+					}
+				}
+				if !fieldInfo.Anonymous || jsonInfo.Name != "" {
+					if jsonInfo.AsString {
+						member.Type = g.getTypeForKind(tsKeywordType("string"), fieldInfo.Type.Kind())
+					} else if tInfo.IsGenericType {
+						member.Type = g.getTypingNameForGeneric(t, fieldInfo, i, tInfo.Generic)
 					} else if ft.Kind() == reflect.Struct && ft.Name() == "" {
-
-						if fieldInfo.Type.Kind() == reflect.Pointer {
-							g.outLine(fmt.Sprintf("%s: null | {", fieldInfo.Name))
-						} else {
-							g.outLine(fmt.Sprintf("%s: {", fieldInfo.Name))
-						}
-						g.doIndent()
 						memberInfo := getTypeInfo(ft)
 						membersResult := g.writeMembers(ft, memberInfo)
 						usedTypes = append(usedTypes, membersResult.usedTypes...)
-						g.doDeIndent()
-						g.outLine("}")
+						usedTypes = append(usedTypes, membersResult.andAlso...)
+
+						member.Type = &tsType{Kind: tsObject, Members: membersResult.members, Extends: membersResult.extends}
+						if fieldInfo.Type.Kind() == reflect.Pointer {
+							member.Type = tsNullableType(member.Type)
+						}
 						dumpMemberType = false
 					} else {
-						g.outLine(fmt.Sprintf("%s: %s", fieldInfo.Name, g.getTypingName(fieldInfo.Type)))
+						member.Type = g.getTypingName(fieldInfo.Type)
+					}
+					if member.Optional {
+						member.Type = getOmitEmptyType(member.Type, fieldInfo.Type.Kind())
 					}
+					members = append(members, member)
 				}
+				leave()
 
 				if dumpMemberType {
 					switch ft.Kind() {
-					case reflect.Slice:
+					case reflect.Slice, reflect.Array:
 						usedTypes = append(usedTypes, getUnderlyingType(ft.Elem()))
 					case reflect.Map:
-						usedTypes = append(usedTypes, getUnderlyingType(ft.Key()), getUnderlyingType(ft.Elem()))
+						// key is written as type only if it has known values
+						if len(g.values[getTypeID(ft.Key())]) > 0 {
+							usedTypes = append(usedTypes, getUnderlyingType(ft.Key()))
+						}
+						usedTypes = append(usedTypes, getUnderlyingType(ft.Elem()))
+					case reflect.Func:
+						for pI := 0; pI < ft.NumIn(); pI++ {
+							usedTypes = append(usedTypes, getUnderlyingType(ft.In(pI)))
+						}
+						for rI := 0; rI < ft.NumOut(); rI++ {
+							usedTypes = append(usedTypes, getUnderlyingType(ft.Out(rI)))
+						}
 					default:
 						usedTypes = append(usedTypes, getUnderlyingType(ft))
 					}
@@ -261,160 +553,199 @@ func (g *definitionGenerator) writeMembers(t reflect.Type, tInfo exTypeInfo) str
 			}
 		}
 
-		if t.Kind() == reflect.Interface {
-			mr := g.writeMethods(t)
-			andAlso = append(andAlso, mr.andAlso...)
-			usedTypes = append(usedTypes, mr.usedTypes...)
-		} else {
-			ptrType := reflect.PointerTo(t)
-			mr := g.writeMethods(ptrType)
+		if g.includesMethods() {
+			var mr membersResult
+			if t.Kind() == reflect.Interface {
+				mr = g.writeMethods(t)
+			} else {
+				ptrType := reflect.PointerTo(t)
+				mr = g.writeMethods(ptrType)
+			}
+			members = append(members, mr.members...)
 			andAlso = append(andAlso, mr.andAlso...)
+			extends = append(extends, mr.extends...)
 			usedTypes = append(usedTypes, mr.usedTypes...)
 		}
 	}
 
-	return struct {
-		andAlso   []reflect.Type
-		usedTypes []reflect.Type
-	}{
+	return membersResult{
+		members,
 		andAlso,
+		extends,
 		usedTypes,
 	}
 }
 
-func (g *definitionGenerator) writeMethods(ptrType reflect.Type) struct {
-	andAlso   []reflect.Type
-	usedTypes []reflect.Type
-} {
+func (g *definitionGenerator) writeMethods(ptrType reflect.Type) membersResult {
+	members := []*tsMember{}
 	usedTypes := []reflect.Type{}
 	andAlso := []reflect.Type{}
 
 	isInterface := ptrType.Kind() == reflect.Interface
 	for i := 0; i < ptrType.NumMethod(); i++ {
 		methodInfo := ptrType.Method(i)
-
-		numParams := methodInfo.Type.NumIn()
-		numResults := methodInfo.Type.NumOut()
-		if numResults > 1 {
-			// TODO configure to panic
-			g.outLine(fmt.Sprintf("// multiple results %s", methodInfo.Name))
+		name := g.getMethodName(ptrType, methodInfo)
+		if name == "" {
 			continue
 		}
 
-		paramsStr := []string{}
-
+		receivers := 1
 		if isInterface {
-			for pI := 0; pI < numParams; pI++ {
-				prmType := methodInfo.Type.In(pI)
-				usedTypes = append(usedTypes, prmType)
-				paramsStr = append(paramsStr, fmt.Sprintf("p%d: %s", pI+1, g.getTypingName(prmType)))
-				usedTypes = append(usedTypes, prmType)
-			}
-		} else {
-			for pI := 0; pI < numParams; pI++ {
-				prmType := methodInfo.Type.In(pI)
-				if pI == 0 {
-				} else {
-					usedTypes = append(usedTypes, prmType)
-
-					paramsStr = append(paramsStr, fmt.Sprintf("p%d: %s", pI, g.getTypingName(prmType)))
-					usedTypes = append(usedTypes, prmType)
-				}
-			}
+			receivers = 0
 		}
-
-		if numResults == 0 {
-			g.outLine(fmt.Sprintf("%s(%s): void", methodInfo.Name, strings.Join(paramsStr, ", ")))
-		} else {
-			resultType := methodInfo.Type.Out(0)
-			resultStr := []string{}
-			switch resultType.Kind() {
-			case reflect.Pointer:
-				{
-					resultStr = append(resultStr, g.getTypingName(resultType))
-					usedTypes = append(usedTypes, resultType.Elem())
-				}
-			case reflect.Struct:
-				{
-					resultStr = append(resultStr, g.getTypingName(resultType))
-					usedTypes = append(usedTypes, resultType)
-				}
-			case reflect.Slice:
-				{
-					resultStr = append(resultStr, g.getTypingName(resultType))
-					usedTypes = append(usedTypes, resultType.Elem())
-				}
-			case reflect.Map:
-				{
-					resultStr = append(resultStr, g.getTypingName(resultType))
-				}
-			default:
-				resultStr = append(resultStr, g.getTypingName(resultType))
-				usedTypes = append(usedTypes, resultType)
-			}
-
-			if len(resultStr) > 1 {
-				panic("not supported")
-			}
-			g.outLine(fmt.Sprintf("%s(%s): %s", methodInfo.Name, strings.Join(paramsStr, ", "), strings.Join(resultStr, "  ")))
+		leave := g.enter("." + methodInfo.Name + "()")
+		fn, methodUsedTypes := g.getFuncSignature(methodInfo.Type, receivers)
+		if fn == nil {
+			g.report("method with multiple results")
+			leave()
+			members = append(members, &tsMember{Comment: fmt.Sprintf("multiple results %s", methodInfo.Name)})
+			continue
+		}
+		leave()
+
+		member := &tsMember{
+			Name:     name,
+			IsMethod: true,
+			Params:   fn.Params,
+			Result:   fn.Result,
+			Throws:   fn.Throws,
 		}
+		usedTypes = append(usedTypes, methodUsedTypes...)
+		members = append(members, member)
 	}
-	return struct {
-		andAlso   []reflect.Type
-		usedTypes []reflect.Type
-	}{
+	return membersResult{
+		members,
 		andAlso,
+		nil,
 		usedTypes,
 	}
 }
 
-func (g *definitionGenerator) getTypingName(t reflect.Type) string {
-	tInfo := getTypeInfo(getUnderlyingType(t))
-	isAlias := tInfo.IsAlias
+// Returns function type, skips given number of first parameters (receiver of method). Function type is nil if results can't be written.
+func (g *definitionGenerator) getFuncSignature(ft reflect.Type, skip int) (*tsType, []reflect.Type) {
+	params := []*tsParam{}
+	usedTypes := []reflect.Type{}
+	for pI := skip; pI < ft.NumIn(); pI++ {
+		prmType := ft.In(pI)
+		usedTypes = append(usedTypes, prmType)
+		params = append(params, &tsParam{
+			Name:     fmt.Sprintf("p%d", pI-skip+1),
+			Type:     g.getTypingName(prmType),
+			Variadic: ft.IsVariadic() && pI == ft.NumIn()-1,
+		})
+	}
 
-	typeName := t.Name()
-	if !isAlias {
-		switch t.Kind() {
-		case reflect.String,
-			reflect.Bool,
-			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-			reflect.Float32, reflect.Float64,
-			reflect.Uintptr, reflect.Complex64, reflect.Complex128:
-			typeName = g.getTypingNameForBase(t.Kind())
-
-		case reflect.Pointer:
-			typeName = fmt.Sprintf("null | %s", g.getTypingName(t.Elem()))
-		case reflect.Slice:
-			if t.Elem().Kind() == reflect.Pointer {
-				typeName = fmt.Sprintf("(%s)[]", g.getTypingName(t.Elem()))
-			} else {
-				typeName = g.getTypingName(t.Elem()) + "[]"
-			}
-		case reflect.Interface:
-			if tInfo.FullBaseTypeName == "interface {}" {
-				typeName = "any"
-			} else {
-				typeName = fmt.Sprintf("null | %s", typeName)
-			}
+	lastIsError := ft.NumOut() > 0 && ft.Out(ft.NumOut()-1) == errorType
+	results := []*tsType{}
+	for rI := 0; rI < ft.NumOut(); rI++ {
+		resultType := ft.Out(rI)
+		if rI == ft.NumOut()-1 && lastIsError && g.throwsErrors() {
+			// thrown, not written
+			results = append(results, nil)
+			break
+		}
+		results = append(results, g.getTypingName(resultType))
+		switch resultType.Kind() {
+		case reflect.Pointer, reflect.Slice:
+			usedTypes = append(usedTypes, resultType.Elem())
 		case reflect.Map:
-			keyType := g.getTypingName(t.Key())
-			elemType := g.getTypingName(t.Elem())
-			typeName = fmt.Sprintf("Record<%s, %s>", keyType, elemType)
-		case reflect.Struct:
-			isSubGeneric := getTypeInfo(t)
-			if isSubGeneric.IsGenericType {
-				typeName = fmt.Sprintf("%s<%s>", isSubGeneric.BaseType, isSubGeneric.TypeParam)
-			} else if !g.shouldWriteType(t, tInfo) {
-				typeName = "unknown"
-			} else {
-				typeName = t.Name()
-			}
 		default:
-			typeName = t.Name()
+			usedTypes = append(usedTypes, resultType)
 		}
 	}
-	return typeName
+	return g.getFuncType(params, results, lastIsError), usedTypes
+}
+
+func (g *definitionGenerator) getTypingName(t reflect.Type) *tsType {
+	if tsType, isMapped := g.getMappedType(t); isMapped {
+		return tsKeywordType(tsType)
+	}
+	if t.Name() != "" {
+		g.reach(getTypeID(t))
+	}
+	// aliases from packages which are not generated are written as base type
+	if t.Kind() != reflect.Pointer && isAliasToBaseType(t) && (g.includesPackage(t.PkgPath()) || g.excluded[getTypeID(t)]) {
+		return tsRefType(getTypeID(t), t.Name())
+	}
+
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Uintptr, reflect.Complex64, reflect.Complex128:
+		return tsKeywordType(g.getTypingNameForBase(t.Kind()))
+
+	case reflect.Pointer:
+		return tsNullableType(g.getTypingName(t.Elem()))
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return g.getBytesType(getTypeID(t))
+		}
+		defer g.enter("[]")()
+		return g.getSliceType(g.getTypingName(t.Elem()))
+	case reflect.Array:
+		defer g.enter("[]")()
+		return g.getArrayType(g.getTypingName(t.Elem()), t.Len())
+	case reflect.Interface:
+		if t.Name() == "" && t.NumMethod() == 0 {
+			return tsKeywordType("any")
+		}
+		if !g.shouldWriteType(t, getTypeInfo(t)) && !g.excluded[getTypeID(t)] {
+			g.reportNotGenerated(t.String(), t.Name(), t.PkgPath())
+			return tsKeywordType("unknown")
+		}
+		return tsNullableType(tsRefType(getTypeID(t), t.Name()))
+	case reflect.Map:
+		leave := g.enter("[]")
+		elem := g.getTypingName(t.Elem())
+		leave()
+		return g.getTypingNameForMap(t.Key(), elem)
+	case reflect.Struct:
+		tInfo := getTypeInfo(t)
+		if tInfo.IsGenericType {
+			return g.getTypingNameForGenericInstance(t, tInfo)
+		} else if !g.shouldWriteType(t, tInfo) && !g.excluded[getTypeID(t)] {
+			if !g.includesPackage(t.PkgPath()) {
+				g.report("type %s from package which is not generated", t)
+			}
+			return tsKeywordType("unknown")
+		}
+		return tsRefType(getTypeID(t), t.Name())
+	case reflect.Func:
+		if !g.includesFuncs() {
+			return g.getTypingNameForUnsupported(t)
+		}
+		if fn, _ := g.getFuncSignature(t, 0); fn != nil {
+			return fn
+		}
+		return g.getTypingNameForUnsupported(t)
+	default:
+		return g.getTypingNameForUnsupported(t)
+	}
+}
+
+// Returns type of map with given key type, reports error if key can't be represented.
+func (g *definitionGenerator) getTypingNameForMap(key reflect.Type, elem *tsType) *tsType {
+	if len(g.values[getTypeID(key)]) > 0 && g.isEnumKey(key.Kind()) {
+		return g.getMapType(g.getTypingName(key), elem, true)
+	}
+	keyType := g.getMapKeyType(key.Kind(), key.Implements(textMarshalerType))
+	if keyType == nil {
+		g.report("unsupported map key type %s", key)
+		return tsKeywordType("unknown")
+	}
+	return g.getMapType(keyType, elem, false)
+}
+
+// Returns type mapped by configuration for type not supported in typescript (eg. chan), reports error if kind is not mapped.
+func (g *definitionGenerator) getTypingNameForUnsupported(t reflect.Type) *tsType {
+	if tsType, ok := g.kinds[t.Kind()]; ok {
+		return tsKeywordType(tsType)
+	}
+	g.report("unsupported type %s", t)
+	return tsKeywordType("unknown")
 }
 
 func (g *definitionGenerator) getTypingNameForBase(k reflect.Kind) string {
@@ -435,21 +766,140 @@ func (g *definitionGenerator) getTypingNameForBase(k reflect.Kind) string {
 	return typeName
 }
 
-func (g *definitionGenerator) getTypingNameForGeneric(fieldInfo reflect.StructField) string {
-	_, isGenericParam := fieldInfo.Tag.Lookup("waxGeneric")
+func (g *definitionGenerator) getTypingNameForEmbedded(t reflect.Type) *tsType {
+	tInfo := getTypeInfo(t)
+	if tInfo.IsGenericType {
+		return g.getTypingNameForGenericInstance(t, tInfo)
+	}
+	return tsRefType(getTypeID(t), t.Name())
+}
+
+// Returns type of embedded field of generic type, type arguments of embedded generic type can be type parameters (eg. Base[T]).
+func (g *definitionGenerator) getTypingNameForGenericEmbedded(t reflect.Type, ft reflect.Type, fieldIndex int, params genericParams) *tsType {
+	pattern, ok := params.Patterns[fieldIndex]
+	if !ok {
+		return g.getTypingNameForEmbedded(ft)
+	}
+	if pattern.Kind == typeExprPointer {
+		pattern = pattern.Elems[0]
+	}
+	knownTypes := map[string]reflect.Type{}
+	collectTypeArgCandidates(t, knownTypes, map[reflect.Type]bool{})
+	return g.getTypingNameForTypeExpr(pattern, params.Names, knownTypes)
+}
+
+func (g *definitionGenerator) getTypingNameForGeneric(t reflect.Type, fieldInfo reflect.StructField, fieldIndex int, params genericParams) *tsType {
+	param, isGenericParam := params.Fields[fieldIndex]
 
 	if !isGenericParam {
+		if pattern, ok := params.Patterns[fieldIndex]; ok {
+			knownTypes := map[string]reflect.Type{}
+			collectTypeArgCandidates(t, knownTypes, map[reflect.Type]bool{})
+			return g.getTypingNameForTypeExpr(pattern, params.Names, knownTypes)
+		}
 		return g.getTypingName(fieldInfo.Type)
 	}
-	return getTypeForKind("T", fieldInfo.Type.Kind())
+	return g.getTypeForKind(tsTypeParamType(params.Names[param.Param]), param.Wrap)
+}
+
+func (g *definitionGenerator) getTypingNameForGenericInstance(t reflect.Type, tInfo exTypeInfo) *tsType {
+	knownTypes := map[string]reflect.Type{}
+	collectTypeArgCandidates(t, knownTypes, map[reflect.Type]bool{})
+
+	args := []*tsType{}
+	for _, a := range tInfo.TypeParams {
+		args = append(args, g.getTypingNameForTypeArg(a, knownTypes))
+	}
+	return tsRefType(getTypeID(t), tInfo.BaseType, args...)
 }
 
-func getTypeForKind(t string, kind reflect.Kind) string {
+// Translates type argument (as written in name of generic type) to typescript type.
+//
+// Uses reflect type if type argument is used by generic type, otherwise parses argument.
+func (g *definitionGenerator) getTypingNameForTypeArg(arg string, knownTypes map[string]reflect.Type) *tsType {
+	return g.getTypingNameForTypeExpr(parseTypeExpr(arg), nil, knownTypes)
+}
+
+func (g *definitionGenerator) getTypingNameForTypeExpr(e *typeExpr, paramNames []string, knownTypes map[string]reflect.Type) *tsType {
+	if e.Kind == typeExprParam {
+		return tsTypeParamType(paramNames[e.Param])
+	}
+	if t, ok := knownTypes[e.Text]; ok && !e.hasParam() {
+		return g.getTypingName(t)
+	}
+
+	switch e.Kind {
+	case typeExprPointer:
+		return tsNullableType(g.getTypingNameForTypeExpr(e.Elems[0], paramNames, knownTypes))
+	case typeExprMap:
+		elemType := g.getTypingNameForTypeExpr(e.Elems[1], paramNames, knownTypes)
+		if t, ok := knownTypes[e.Elems[0].Text]; ok && !e.Elems[0].hasParam() {
+			return g.getTypingNameForMap(t, elemType)
+		}
+		if k, ok := basicKindsByName[e.Elems[0].Name]; ok && e.Elems[0].Kind == typeExprNamed {
+			keyType := g.getMapKeyType(k, false)
+			if keyType == nil {
+				g.report("unsupported map key type %s", e.Elems[0].Text)
+				return tsKeywordType("unknown")
+			}
+			return g.getMapType(keyType, elemType, false)
+		}
+		keyType := g.getTypingNameForTypeExpr(e.Elems[0], paramNames, knownTypes)
+		return g.getMapType(keyType, elemType, false)
+	case typeExprSlice:
+		return g.getSliceType(g.getTypingNameForTypeExpr(e.Elems[0], paramNames, knownTypes))
+	case typeExprArray:
+		return tsArrayType(g.getTypingNameForTypeExpr(e.Elems[0], paramNames, knownTypes))
+	case typeExprNamed:
+		if k, ok := basicKindsByName[e.Name]; ok {
+			return tsKeywordType(g.getTypingNameForBase(k))
+		}
+		if tsType, isMapped := g.mapping.lookupName(e.Name); isMapped {
+			return tsKeywordType(tsType)
+		}
+		pkgPath, shortName := "", e.Name
+		if i := strings.LastIndex(e.Name, "."); i >= 0 {
+			pkgPath, shortName = e.Name[:i], e.Name[i+1:]
+		}
+		if pkgPath == "" || !g.includesPackage(pkgPath) {
+			g.report("type %s from package which is not generated", e.Name)
+			return tsKeywordType("unknown")
+		}
+		args := []*tsType{}
+		for _, a := range e.Elems {
+			args = append(args, g.getTypingNameForTypeExpr(a, paramNames, knownTypes))
+		}
+		return tsRefType(e.Name, shortName, args...)
+	}
+	if e.Text == "interface {}" {
+		return tsKeywordType("any")
+	}
+	return tsKeywordType("unknown")
+}
+
+// Returns typescript literal for value.
+func getReflectValueLiteral(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	return "unknown"
+}
+
+func (g *definitionGenerator) getTypeForKind(t *tsType, kind reflect.Kind) *tsType {
 	switch kind {
 	case reflect.Pointer:
-		return "null | " + t
+		return tsNullableType(t)
 	case reflect.Slice:
-		return "" + t + "[]"
+		return g.getSliceType(t)
 	default:
 		return t
 	}
@@ -464,6 +914,36 @@ func getUnderlyingType(t reflect.Type) reflect.Type {
 	}
 }
 
+// Returns named types used by type, unnamed types (slices, maps, functions, structs) are expanded.
+func getUsedTypes(t reflect.Type) []reflect.Type {
+	t = getUnderlyingType(t)
+	if t.Name() != "" {
+		return []reflect.Type{t}
+	}
+	result := []reflect.Type{}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Chan:
+		result = append(result, getUsedTypes(t.Elem())...)
+	case reflect.Map:
+		result = append(result, getUsedTypes(t.Key())...)
+		result = append(result, getUsedTypes(t.Elem())...)
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			result = append(result, getUsedTypes(t.In(i))...)
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			result = append(result, getUsedTypes(t.Out(i))...)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				result = append(result, getUsedTypes(t.Field(i).Type)...)
+			}
+		}
+	}
+	return result
+}
+
 func isAliasToBaseType(t reflect.Type) bool {
 	return t.PkgPath() != "" && isBaseType(t)
 }
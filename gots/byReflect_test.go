@@ -2,7 +2,11 @@ package gots_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -65,10 +69,18 @@ type DummySimpleGeneric[T any] struct {
 type DummyMaps struct {
 	Map1 map[string]int
 	Map2 map[string]DummySimple
-	Map3 map[DummySimple]int
+	Map3 map[int]int
 	Map4 map[string]DummySimpleGeneric[DummySimple]
 	Map5 map[string]any
-	Map6 map[any]int
+	Map6 map[Color]int
+	Map7 map[AccountStatus]int
+	Map8 map[Level]string
+}
+
+type DummyInvalidMaps struct {
+	Map1 map[DummySimple]int
+	Map2 map[any]int
+	Map3 map[float64]int
 }
 
 func (t *StringAlias) SomeAliasTrueMethod() bool {
@@ -136,6 +148,124 @@ type DummyInterface interface {
 	Func4(p any) string
 }
 
+type DummyJSON struct {
+	Name     string       `json:"name"`
+	Email    string       `json:"email,omitempty"`
+	Ignored  string       `json:"-"`
+	Dash     string       `json:"-,"`
+	Count    int64        `json:"count,string"`
+	CountPtr *int         `json:",string"`
+	Kebab    string       `json:"kebab-case"`
+	Nested   *DummySimple `json:"nested,omitempty"`
+	DummySimple
+	Named DummySimpleNamedEmbed `json:"named"`
+}
+
+type DummySimpleNamedEmbed struct {
+	Field string `json:"field"`
+}
+
+type Pair[K comparable, V any] struct {
+	Key    K   `waxGeneric:"K"`
+	Value  V   `waxGeneric:"V"`
+	Values []V `waxGeneric:"V"`
+}
+
+type Result[T, E any] struct {
+	Value *T `waxGeneric:""`
+	Err   E  `waxGeneric:""`
+	Ok    bool
+}
+
+type DummyMultiGeneric struct {
+	Pair     Pair[string, int]
+	SamePair Pair[string, string]
+	Result   Result[Contact, string]
+}
+
+type Page[T any] struct {
+	Items []T `waxGeneric:""`
+	Total int
+}
+
+type Ref[T any] struct {
+	ID string
+}
+
+type DummyGenericArgs struct {
+	ContactPtr  TestGeneric[*Contact]
+	StringArr   TestGeneric[[]string]
+	NestedPairs Page[Pair[string, int]]
+	Ref         Ref[Contact]
+	RefMap      Ref[map[string]*Contact]
+}
+
+type Inferred[K comparable, V any] struct {
+	Key    K
+	Values []V
+	Ptr    *V
+	ByName map[string]V
+	Nested Page[V]
+	Label  string
+	Fixed  Contact `waxGeneric:"-"`
+}
+
+type DummyInferred struct {
+	A Inferred[string, int]
+	B Inferred[int, Contact]
+}
+
+type Box[T any] struct {
+	Value T
+	Name  string
+}
+
+type DummyBox struct {
+	Box Box[string]
+}
+
+type AccountStatus string
+
+const (
+	AccountActive   AccountStatus = "active"
+	AccountDisabled AccountStatus = "disabled"
+)
+
+type Level int
+
+const (
+	LevelLow  Level = 1
+	LevelHigh Level = 2
+)
+
+type DummyEnum struct {
+	Status AccountStatus
+	Level  Level
+}
+
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+type Color struct {
+	R, G, B uint8
+}
+
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+type DummyMapped struct {
+	Price     Money
+	Color     Color
+	ColorPtr  *Color
+	Created   time.Time
+	Timeout   time.Duration
+	Raw       json.RawMessage
+	Favorites []Color
+}
+
 func thisPackageOnly() string {
 	pp := reflect.TypeOf(TestStruct{})
 	return pp.PkgPath()
@@ -195,7 +325,7 @@ type TestStruct = {
   ArrPtr: null | string[]
   AliasToString: StringAlias
   GenericDataString: TestGeneric<string>
-  GenericDataInt: TestGeneric<int>
+  GenericDataInt: TestGeneric<number>
   GenericDataStringAlias: TestGeneric<StringAlias>
   GenericDataContact: TestGeneric<Contact>
   ArrayWithGeneric: TestGeneric<Contact>[]
@@ -225,7 +355,7 @@ type TestGeneric<T> = {
 
 func Test_Maps(t *testing.T) {
 	buf := bytes.NewBufferString("")
-	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyMaps{})
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyMaps{}, gots.Enum(AccountActive, AccountDisabled), gots.Enum(LevelLow, LevelHigh))
 	if err != nil {
 		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
 	}
@@ -233,12 +363,18 @@ func Test_Maps(t *testing.T) {
 type DummyMaps = {
   Map1: Record<string, number>
   Map2: Record<string, DummySimple>
-  Map3: Record<DummySimple, number>
+  Map3: Record<number, number>
   Map4: Record<string, DummySimpleGeneric<DummySimple>>
   Map5: Record<string, any>
-  Map6: Record<any, number>
+  Map6: Record<string, number>
+  Map7: { [K in AccountStatus]?: number }
+  Map8: { [K in Level]?: string }
 }
 
+type AccountStatus = "active" | "disabled"
+
+type Level = 1 | 2
+
 type DummySimple = {
   DummySimpleField: string
 }
@@ -246,12 +382,56 @@ type DummySimple = {
 type DummySimpleGeneric<T> = {
   GenericField: T
 }
-
 `
 	actual := buf.String()
 	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
 		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
 	}
+
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON)).
+		Add(DummyMaps{}, gots.Enum(AccountActive, AccountDisabled), gots.Enum(LevelLow, LevelHigh)).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+type DummyMaps = {
+  Map1: null | Record<string, number>
+  Map2: null | Record<string, DummySimple>
+  Map3: null | Record<string, number>
+  Map4: null | Record<string, DummySimpleGeneric<DummySimple>>
+  Map5: null | Record<string, any>
+  Map6: null | Record<string, number>
+  Map7: null | { [K in AccountStatus]?: number }
+  Map8: null | Record<string, string>
+}
+
+type AccountStatus = "active" | "disabled"
+
+type Level = 1 | 2
+
+type DummySimple = {
+  DummySimpleField: string
+}
+
+type DummySimpleGeneric<T> = {
+  GenericField: T
+}
+`
+	actual = buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithStrict()).
+		Add(DummyInvalidMaps{}).
+		Generate(bytes.NewBufferString(""))
+	for i, key := range []string{"gots_test.DummySimple", "interface {}", "float64"} {
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("DummyInvalidMaps.Map%d: unsupported map key type %s", i+1, key)) {
+			t.Errorf("Expected error for map key %s, got %v", key, err)
+		}
+	}
 }
 
 func Test_Interface(t *testing.T) {
@@ -310,7 +490,7 @@ func Test_DummyWithOtherPkgType(t *testing.T) {
 	}
 	expected := `        
 type DummyWithOtherPkgType = {
-  Time: unknown
+  Time: string
   TestingB: unknown
   Foo: any
 }
@@ -356,7 +536,7 @@ type DummyTest = {
   Deposit: number
   Other: null | DummyTest
   OtherSimple: DummySimple
-  GenericSimple: DummySimpleGeneric<int>
+  GenericSimple: DummySimpleGeneric<number>
 } & DummySimple
 
 type DummySimple = {
@@ -372,3 +552,602 @@ type DummySimpleGeneric<T> = {
 		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
 	}
 }
+
+func Test_JSONTags(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyJSON{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyJSON = {
+  name: string
+  email?: string
+  "-": string
+  count: string
+  CountPtr: null | string
+  "kebab-case": string
+  nested?: null | DummySimple
+  named: DummySimpleNamedEmbed
+} & DummySimple
+
+type DummySimple = {
+  DummySimpleField: string
+}
+
+type DummySimpleNamedEmbed = {
+  field: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_MultipleGenericParams(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyMultiGeneric{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyMultiGeneric = {
+  Pair: Pair<string, number>
+  SamePair: Pair<string, string>
+  Result: Result<Contact, string>
+}
+
+type Pair<K, V> = {
+  Key: K
+  Value: V
+  Values: V[]
+}
+
+type Result<T1, T2> = {
+  Value: null | T1
+  Err: T2
+  Ok: boolean
+}
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_GenericTypeArgs(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyGenericArgs{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyGenericArgs = {
+  ContactPtr: TestGeneric<null | Contact>
+  StringArr: TestGeneric<string[]>
+  NestedPairs: Page<Pair<string, number>>
+  Ref: Ref<Contact>
+  RefMap: Ref<Record<string, null | Contact>>
+}
+
+type TestGeneric<T> = {
+  Data: T[]
+  P1: string
+  P2: boolean
+  P3: T
+}
+
+type Page<T> = {
+  Items: T[]
+  Total: number
+}
+
+type Ref<T> = {
+  ID: string
+}
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+
+type Pair<K, V> = {
+  Key: K
+  Value: V
+  Values: V[]
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_GenericInferred(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyInferred{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyInferred = {
+  A: Inferred<string, number>
+  B: Inferred<number, Contact>
+}
+
+type Inferred<T1, T2> = {
+  Key: T1
+  Values: T2[]
+  Ptr: null | T2
+  ByName: Record<string, T2>
+  Nested: Page<T2>
+  Label: string
+  Fixed: Contact
+}
+
+type Page<T> = {
+  Items: T[]
+  Total: number
+}
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_GenericSample(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyBox{}, Box[gots.T1]{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyBox = {
+  Box: Box<string>
+}
+
+type Box<T> = {
+  Value: T
+  Name: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type Envelope[T any] struct {
+	Data   T `waxGeneric:"T"`
+	Sender Contact
+}
+
+type DummyEnvelope struct {
+	Envelope Envelope[Contact]
+}
+
+func Test_GenericSingleInstance(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyEnvelope{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyEnvelope = {
+  Envelope: Envelope<Contact>
+}
+
+type Envelope<T> = {
+  Data: T
+  Sender: Contact
+}
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type InferBox[T any] struct {
+	Value T
+	Label string
+}
+
+type InferPage[T any] struct {
+	Items []T
+	Total int
+}
+
+type InferPair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+type DummyInferredSingle struct {
+	B InferBox[int]
+	P InferPage[InferPair[string, int]]
+	// ambiguous, string is used by Value and Label
+	L InferBox[string]
+}
+
+func Test_GenericSingleInstanceInferred(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	g := gots.NewGenerator(gots.WithPackages(thisPackageOnly())).Add(DummyInferredSingle{})
+	err := g.Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyInferredSingle = {
+  B: InferBox<number>
+  P: InferPage<InferPair<string, number>>
+  L: InferBox<string>
+}
+
+type InferBox<T> = {
+  Value: T
+  Label: string
+}
+
+type InferPage<T> = {
+  Items: T[]
+  Total: number
+}
+
+type InferPair<T1, T2> = {
+  Key: T1
+  Val: T2
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	// without ambiguous instance
+	g = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithStrict()).Add(InferBox[string]{})
+	err = g.Generate(bytes.NewBufferString(""))
+	var diagErr *gots.DiagnosticsError
+	if !errors.As(err, &diagErr) {
+		t.Fatalf("Expected diagnostics error, got %v", err)
+	}
+	expectedDiagnostics := []gots.Diagnostic{{Path: "InferBox", Reason: "type parameter T is not used by fields (mark field with waxGeneric tag)"}}
+	if !slices.Equal(diagErr.Diagnostics, expectedDiagnostics) {
+		t.Errorf("Diagnostics not as expected: %v", diagErr.Diagnostics)
+	}
+}
+
+type EmbeddedBase[T any] struct {
+	ID T
+}
+
+type EmbeddedEnt[T any] struct {
+	EmbeddedBase[T]
+	Name string
+}
+
+type DummyEmbeddedGeneric struct {
+	ByNumber EmbeddedEnt[int]
+	ByString EmbeddedEnt[string]
+}
+
+func Test_GenericEmbedded(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyEmbeddedGeneric{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyEmbeddedGeneric = {
+  ByNumber: EmbeddedEnt<number>
+  ByString: EmbeddedEnt<string>
+}
+
+type EmbeddedEnt<T> = {
+  Name: string
+} & EmbeddedBase<T>
+
+type EmbeddedBase<T> = {
+  ID: T
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_Enum(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyEnum{}, gots.Enum(AccountActive, AccountDisabled), gots.Enum(LevelLow, LevelHigh))
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyEnum = {
+  Status: AccountStatus
+  Level: Level
+}
+
+type AccountStatus = "active" | "disabled"
+
+type Level = 1 | 2
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_TypeMapping(t *testing.T) {
+	defer func(m *gots.TypeMapping) { gots.TypeMappings = m }(gots.TypeMappings)
+	gots.TypeMappings = gots.TypeMappings.Clone().Map(Money{}, "string")
+
+	buf := bytes.NewBufferString("")
+	// explicit mapping wins over known values
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyMapped{}, gots.Enum(time.Second, time.Minute))
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyMapped = {
+  Price: string
+  Color: Color
+  ColorPtr: null | Color
+  Created: string
+  Timeout: number
+  Raw: number[]
+  Favorites: Color[]
+}
+
+type Color = {
+  R: number
+  G: number
+  B: number
+  /** @throws error returned by go function */
+  MarshalText(): number[]
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	// marshalers are used only by encoding/json
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON)).
+		Add(DummyMapped{}, gots.Enum(time.Second, time.Minute)).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+type DummyMapped = {
+  Price: string
+  Color: string
+  ColorPtr: null | string
+  Created: string
+  Timeout: number
+  Raw: unknown
+  Favorites: null | string[]
+}
+`
+	actual = buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type DummyCallbacks struct {
+	OnClick    func(x int, y int) bool
+	OnSelect   func(*Contact)
+	Format     func(string, ...any) string
+	Validators []func(string) error
+	Updates    chan int
+}
+
+func Test_FuncFields(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithKindMapping(reflect.Chan, "unknown")).
+		Add(DummyCallbacks{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyCallbacks = {
+  OnClick: (p1: number, p2: number) => boolean
+  OnSelect: (p1: null | Contact) => void
+  Format: (p1: string, ...p2: any[]) => string
+  Validators: ((p1: string) => void)[]
+  Updates: unknown
+}
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithStrict()).
+		Add(DummyCallbacks{}).
+		Generate(buf)
+	if err == nil || !strings.Contains(err.Error(), "DummyCallbacks.Updates: unsupported type chan int") {
+		t.Errorf("Expected error for unsupported type, got %v", err)
+	}
+}
+
+type DummyArrays struct {
+	Point  [3]float64
+	ID     [16]byte
+	Pairs  [2][2]int
+	Data   []byte
+	Raw    json.RawMessage
+	Chunks [][]byte
+}
+
+func Test_Arrays(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), DummyArrays{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyArrays = {
+  Point: [number, number, number]
+  ID: number[]
+  Pairs: [[number, number], [number, number]]
+  Data: number[]
+  Raw: number[]
+  Chunks: number[][]
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	buf.Reset()
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON), gots.WithMaxTupleLength(2)).
+		Add(DummyArrays{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+type DummyArrays = {
+  Point: number[]
+  ID: number[]
+  Pairs: [[number, number], [number, number]]
+  Data: null | string
+  Raw: unknown
+  Chunks: null | (null | string)[]
+}
+`
+	actual = buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type DummyResults struct {
+	Load func(id int) (*Contact, error)
+}
+
+func (r DummyResults) Find(name string) (Contact, error) {
+	return Contact{}, nil
+}
+
+func (r DummyResults) Save() error {
+	return nil
+}
+
+func (r DummyResults) Split() (string, string) {
+	return "", ""
+}
+
+func (r DummyResults) Parse() (int, bool, error) {
+	return 0, false, nil
+}
+
+func Test_FuncResults(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithExcluded(Contact{})).
+		Add(DummyResults{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummyResults = {
+  /** @throws error returned by go function */
+  Load: (p1: number) => null | Contact
+  /** @throws error returned by go function */
+  Find(p1: string): Contact
+  /** @throws error returned by go function */
+  Parse(): [number, boolean]
+  /** @throws error returned by go function */
+  Save(): void
+  Split(): [string, string]
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithExcluded(Contact{}), gots.WithFuncResults(gots.FuncResultsSingle)).
+		Add(DummyResults{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+type DummyResults = {
+  Load: unknown
+  // multiple results Find
+  // multiple results Parse
+  Save(): unknown
+  // multiple results Split
+}
+`
+	actual = buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type promotedInner struct {
+	Hidden string
+}
+
+type PromotedOrder struct {
+	promotedInner
+	ID int
+}
+
+func Test_UnexportedEmbedded(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	err := gots.GenerateTypeDefinition(buf, "", thisPackageOnly(), PromotedOrder{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type PromotedOrder = {
+  ID: number
+} & promotedInner
+
+type promotedInner = {
+  Hidden: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
@@ -0,0 +1,854 @@
+package gots
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Generates typescript typings (.d.ts) for types loaded from source of given packages.
+//
+// Unlike GenerateTypeDefinition it writes doc comments as JSDoc and uses names of method parameters.
+//
+// Patterns are resolved by go/packages (eg. "./models/..."). Type names can be qualified with package path (eg. "github.com/some/models.Contact").
+// If no type names are given, types marked with //gots:export directive are generated (or all exported types if none is marked).
+// Types marked with //gots:view directive (eg. //gots:view users/list) are models of views, see View.
+//
+// Can wrap types in given namespace (if empty will omit namespace).
+//
+// If pkg is specified it will generate types in packages containing given prefix.
+//
+// Use Generator for more options.
+func GenerateTypeDefinitionFromSource(out io.StringWriter, namespace string, pkg string, patterns []string, typeNames ...string) error {
+	return NewGenerator(WithNamespace(namespace), WithPackages(pkg)).
+		GenerateFromSource(out, patterns, typeNames...)
+}
+
+func loadPackages(patterns ...string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("loading package %s: %w", p.PkgPath, p.Errors[0])
+		}
+	}
+	return pkgs, nil
+}
+
+type sourceGenerator struct {
+	*options
+
+	// doc comments by position of declared name
+	docs map[token.Pos]string
+	// gots directives (//gots:name) by position of declared name
+	directives map[token.Pos][]string
+	// constants of named types by type ID, collected from packages of declared types
+	values map[string][]*types.Const
+	// included packages by path, values are collected from them when their types are declared
+	packages map[string]*packages.Package
+	// paths of packages from which values were collected
+	scanned        map[string]bool
+	decls          []*tsDecl
+	typesToProcess []*types.Named
+	// views marked with //gots:view directive
+	views []tsView
+	// implementations of interfaces marked with //gots:union directive by interface type ID
+	unions map[string]*sourceUnion
+	// discriminators of union implementations by type ID
+	discriminators map[string][]discriminatorValue
+
+	diagnostics
+}
+
+func (g *sourceGenerator) Generate(pkgs []*packages.Package, typeNames ...string) ([]*tsDecl, error) {
+	// docs and values are collected also from imported packages of generated types
+	deps := []*packages.Package{}
+	g.packages = map[string]*packages.Package{}
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if g.includesPackage(p.PkgPath) {
+			deps = append(deps, p)
+			g.packages[p.PkgPath] = p
+		}
+	})
+	g.values = map[string][]*types.Const{}
+	g.scanned = map[string]bool{}
+	g.collectDocs(deps)
+	g.collectUnions(deps)
+
+	roots, err := g.findNamedTypes(pkgs, typeNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range roots {
+		g.root(getNamedTypeID(t), t.Obj().Name())
+	}
+	processedTypes := map[string]bool{}
+	for _, t := range roots {
+		id := getNamedTypeID(t)
+		if processedTypes[id] || g.excluded[id] {
+			continue
+		}
+		processedTypes[id] = true
+		g.writeType(t)
+	}
+	for _, d := range g.decls {
+		d.Root = true
+	}
+
+	for len(g.typesToProcess) > 0 {
+		t := g.typesToProcess[0]
+		g.typesToProcess = g.typesToProcess[1:]
+
+		id := getNamedTypeID(t)
+		if processedTypes[id] {
+			continue
+		}
+		processedTypes[id] = true
+		if !g.shouldWriteType(t) {
+			continue
+		}
+		g.writeType(t)
+	}
+	views, err := getViewDecls(g.views)
+	if err != nil {
+		return nil, err
+	}
+	g.decls = append(g.decls, views...)
+	if err := g.diagnosticsError(g.list); err != nil {
+		return nil, err
+	}
+	return g.decls, nil
+}
+
+func (g *sourceGenerator) collectDocs(pkgs []*packages.Package) {
+	g.docs = map[token.Pos]string{}
+	g.directives = map[token.Pos][]string{}
+	addDirectives := func(pos token.Pos, doc *ast.CommentGroup) {
+		if doc == nil {
+			return
+		}
+		for _, c := range doc.List {
+			if d, ok := strings.CutPrefix(c.Text, "//gots:"); ok {
+				g.directives[pos] = append(g.directives[pos], strings.TrimSpace(d))
+			}
+		}
+	}
+	addFieldDocs := func(fields *ast.FieldList) {
+		for _, f := range fields.List {
+			doc := f.Doc
+			if doc == nil {
+				doc = f.Comment
+			}
+			for _, n := range f.Names {
+				g.docs[n.Pos()] = doc.Text()
+			}
+		}
+	}
+
+	for _, p := range pkgs {
+		for _, file := range p.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.GenDecl:
+					for _, spec := range n.Specs {
+						if ts, ok := spec.(*ast.TypeSpec); ok {
+							doc := ts.Doc
+							if doc == nil && len(n.Specs) == 1 {
+								doc = n.Doc
+							}
+							g.docs[ts.Name.Pos()] = doc.Text()
+							addDirectives(ts.Name.Pos(), doc)
+						}
+						if vs, ok := spec.(*ast.ValueSpec); ok {
+							doc := vs.Doc
+							if doc == nil {
+								doc = vs.Comment
+							}
+							for _, n := range vs.Names {
+								g.docs[n.Pos()] = doc.Text()
+							}
+						}
+					}
+				case *ast.FuncDecl:
+					g.docs[n.Name.Pos()] = n.Doc.Text()
+				case *ast.StructType:
+					addFieldDocs(n.Fields)
+				case *ast.InterfaceType:
+					addFieldDocs(n.Methods)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// Collects implementations (marked with //gots:variant directive) of interfaces marked with //gots:union directive.
+func (g *sourceGenerator) collectUnions(pkgs []*packages.Package) {
+	g.unions = map[string]*sourceUnion{}
+	g.discriminators = map[string][]discriminatorValue{}
+	ifaces, variants := []*types.Named{}, []*types.Named{}
+	for _, p := range pkgs {
+		objs := []types.Object{}
+		for _, name := range p.Types.Scope().Names() {
+			if tn, ok := p.Types.Scope().Lookup(name).(*types.TypeName); ok && !tn.IsAlias() {
+				objs = append(objs, tn)
+			}
+		}
+		slices.SortFunc(objs, func(a, b types.Object) int { return int(a.Pos() - b.Pos()) })
+		for _, obj := range objs {
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			if _, ok := g.getDirectiveArg(obj.Pos(), "union"); ok && types.IsInterface(named) {
+				ifaces = append(ifaces, named)
+			}
+			if _, ok := g.getDirectiveArg(obj.Pos(), "variant"); ok {
+				variants = append(variants, named)
+			}
+		}
+	}
+	for _, iface := range ifaces {
+		discriminator, _ := g.getDirectiveArg(iface.Obj().Pos(), "union")
+		union := &sourceUnion{discriminator: discriminator}
+		u := iface.Underlying().(*types.Interface)
+		for _, v := range variants {
+			if !types.Implements(v, u) && !types.Implements(types.NewPointer(v), u) {
+				continue
+			}
+			value, _ := g.getDirectiveArg(v.Obj().Pos(), "variant")
+			union.impls = append(union.impls, v)
+			union.values = append(union.values, strconv.Quote(value))
+			id := getNamedTypeID(v)
+			g.discriminators[id] = append(g.discriminators[id], discriminatorValue{Name: discriminator, Value: strconv.Quote(value)})
+		}
+		g.unions[getNamedTypeID(iface)] = union
+	}
+}
+
+// sourceUnion is interface marked with //gots:union directive with its implementations.
+type sourceUnion struct {
+	discriminator string
+	impls         []*types.Named
+	// typescript literals of discriminator by implementation
+	values []string
+}
+
+// Returns constants of named type, they are collected from package of the type when its first type is declared.
+func (g *sourceGenerator) valuesOf(t *types.Named) []*types.Const {
+	if pkg := t.Obj().Pkg(); pkg != nil && !g.scanned[pkg.Path()] {
+		g.scanned[pkg.Path()] = true
+		if p, ok := g.packages[pkg.Path()]; ok {
+			g.collectValues(p)
+		}
+	}
+	return g.values[getNamedTypeID(t)]
+}
+
+// Collects constants of named types declared in package, they are written as union of values.
+//
+// Only enum-like groups are collected: const block with at least two constants of type or constants using iota.
+// Single constants (eg. default value) are collected only for types marked with //gots:enum (or //gots:const) directive.
+func (g *sourceGenerator) collectValues(p *packages.Package) {
+	singles := map[string][]*types.Const{}
+	marked := map[string]bool{}
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			group := map[string][]*types.Const{}
+			usesIota := map[string]bool{}
+			specIota := false
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Values) > 0 {
+					// constants without values repeat previous expression
+					specIota = containsIota(vs.Values)
+				}
+				for _, n := range vs.Names {
+					c, ok := p.TypesInfo.Defs[n].(*types.Const)
+					if !ok {
+						continue
+					}
+					named, ok := types.Unalias(c.Type()).(*types.Named)
+					if !ok || named.Obj().Pkg() != p.Types {
+						// only constants declared with their type
+						continue
+					}
+					id := getNamedTypeID(named)
+					group[id] = append(group[id], c)
+					usesIota[id] = usesIota[id] || specIota
+					marked[id] = g.hasDirective(named.Obj().Pos(), "enum") || g.hasDirective(named.Obj().Pos(), "const")
+				}
+			}
+			for id, consts := range group {
+				if gd.Lparen.IsValid() && len(consts) > 1 || usesIota[id] {
+					g.values[id] = append(g.values[id], consts...)
+				} else {
+					singles[id] = append(singles[id], consts...)
+				}
+			}
+		}
+	}
+	for id, consts := range singles {
+		if marked[id] {
+			g.values[id] = append(g.values[id], consts...)
+		}
+	}
+	for _, consts := range g.values {
+		slices.SortFunc(consts, func(a, b *types.Const) int { return int(a.Pos() - b.Pos()) })
+	}
+}
+
+// Checks if expressions use iota.
+func containsIota(exprs []ast.Expr) bool {
+	found := false
+	for _, e := range exprs {
+		ast.Inspect(e, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && id.Name == "iota" {
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+func (g *sourceGenerator) hasDirective(pos token.Pos, directive string) bool {
+	return slices.Contains(g.directives[pos], directive)
+}
+
+// Returns argument of directive (eg. //gots:name Other).
+func (g *sourceGenerator) getDirectiveArg(pos token.Pos, directive string) (string, bool) {
+	args := g.getDirectiveArgs(pos, directive)
+	if len(args) == 0 {
+		return "", false
+	}
+	return args[0], true
+}
+
+// Returns arguments of all occurrences of directive.
+func (g *sourceGenerator) getDirectiveArgs(pos token.Pos, directive string) []string {
+	result := []string{}
+	for _, d := range g.directives[pos] {
+		if arg, ok := strings.CutPrefix(d, directive+" "); ok {
+			result = append(result, strings.TrimSpace(arg))
+		}
+	}
+	return result
+}
+
+// Finds named types in loaded packages.
+//
+// If no names are given returns types marked with //gots:export or //gots:view directive, if there are none returns all exported types.
+func (g *sourceGenerator) findNamedTypes(pkgs []*packages.Package, typeNames ...string) ([]*types.Named, error) {
+	result := []*types.Named{}
+	if len(typeNames) == 0 {
+		exported := []*types.Named{}
+		for _, p := range pkgs {
+			objs := []types.Object{}
+			for _, name := range p.Types.Scope().Names() {
+				obj := p.Types.Scope().Lookup(name)
+				if tn, ok := obj.(*types.TypeName); ok && tn.Exported() && !tn.IsAlias() {
+					objs = append(objs, obj)
+				}
+			}
+			slices.SortFunc(objs, func(a, b types.Object) int { return int(a.Pos() - b.Pos()) })
+			for _, obj := range objs {
+				named, ok := obj.Type().(*types.Named)
+				if !ok {
+					continue
+				}
+				exported = append(exported, named)
+				if g.hasDirective(obj.Pos(), "export") || len(g.getDirectiveArgs(obj.Pos(), "view")) > 0 {
+					result = append(result, named)
+				}
+			}
+		}
+		if len(result) == 0 {
+			return exported, nil
+		}
+		return result, nil
+	}
+
+	for _, typeName := range typeNames {
+		pkgPath, name := "", typeName
+		if i := strings.LastIndex(typeName, "."); i >= 0 {
+			pkgPath, name = typeName[:i], typeName[i+1:]
+		}
+		var found *types.Named
+		for _, p := range pkgs {
+			if pkgPath != "" && p.PkgPath != pkgPath {
+				continue
+			}
+			if tn, ok := p.Types.Scope().Lookup(name).(*types.TypeName); ok {
+				if named, ok := types.Unalias(tn.Type()).(*types.Named); ok {
+					found = named
+					break
+				}
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("type %s not found", typeName)
+		}
+		result = append(result, found)
+	}
+	return result, nil
+}
+
+// Returns ID of declaration for given type (package path and name without type arguments).
+func getNamedTypeID(t *types.Named) string {
+	obj := t.Obj()
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+func (g *sourceGenerator) shouldWriteType(t *types.Named) bool {
+	obj := t.Obj()
+	if obj.Pkg() == nil {
+		return false
+	}
+	if !g.includesPackage(obj.Pkg().Path()) {
+		return false
+	}
+	if placeholderTypeArgs[getNamedTypeID(t)] || g.excluded[getNamedTypeID(t)] {
+		return false
+	}
+	if _, isMapped := g.getMappedType(t); isMapped {
+		return false
+	}
+	return true
+}
+
+// Returns typescript type if type is mapped by type mapping.
+//
+// Types mapped by name are always mapped, types with known values are not mapped as marshalers.
+func (g *sourceGenerator) getMappedType(t *types.Named) (string, bool) {
+	if tsType, ok := g.mapping.lookupName(getNamedTypeID(t)); ok {
+		return tsType, true
+	}
+	if len(g.valuesOf(t)) > 0 {
+		return "", false
+	}
+	return g.mapping.lookupNamed(t, g.profile.Marshalers)
+}
+
+func (g *sourceGenerator) writeType(t *types.Named) {
+	origin := t.Origin()
+	obj := origin.Obj()
+	decl := &tsDecl{
+		ID:      getNamedTypeID(origin),
+		PkgPath: obj.Pkg().Path(),
+		Name:    obj.Name(),
+		Doc:     g.docs[obj.Pos()],
+	}
+	g.start(decl.ID, obj.Name())
+	if name, ok := g.getDirectiveArg(obj.Pos(), "name"); ok {
+		decl.Name = name
+		decl.ExplicitName = true
+	}
+	for i := 0; i < origin.TypeParams().Len(); i++ {
+		decl.TypeParams = append(decl.TypeParams, origin.TypeParams().At(i).Obj().Name())
+	}
+	if u, isBasic := origin.Underlying().(*types.Basic); isBasic {
+		if g.aliasIsObject() {
+			decl.IsAliasObject = true
+		} else {
+			decl.Type = tsKeywordType(getTypingNameForBasic(u))
+		}
+	}
+	for _, c := range g.valuesOf(origin) {
+		v := &tsValue{
+			Doc:   g.docs[c.Pos()],
+			Value: getValueLiteral(c.Val()),
+		}
+		if c.Exported() {
+			v.Name = getValueName(obj.Name(), c.Name())
+		}
+		decl.Values = append(decl.Values, v)
+	}
+	decl.ValuesObject = g.hasDirective(obj.Pos(), "const")
+	g.decls = append(g.decls, decl)
+	for _, name := range g.getDirectiveArgs(obj.Pos(), "view") {
+		g.views = append(g.views, tsView{Name: name, Model: tsRefType(decl.ID, decl.Name)})
+	}
+
+	if union, ok := g.unions[decl.ID]; ok {
+		g.writeUnion(decl, union)
+		return
+	}
+
+	methods := types.NewMethodSet(types.NewPointer(origin))
+	switch u := origin.Underlying().(type) {
+	case *types.Struct:
+		decl.Members, decl.Extends = g.writeFields(u)
+	case *types.Interface:
+		methods = types.NewMethodSet(u)
+	}
+	if g.includesMethods() {
+		decl.Members = append(decl.Members, g.writeMethods(methods)...)
+	}
+	setDiscriminators(decl, g.discriminators[decl.ID])
+}
+
+// Writes interface as union of its implementations.
+func (g *sourceGenerator) writeUnion(decl *tsDecl, union *sourceUnion) {
+	variants := []*tsVariant{}
+	for i, impl := range union.impls {
+		variants = append(variants, &tsVariant{Value: union.values[i], Type: g.getTypingName(impl)})
+	}
+	setUnion(decl, union.discriminator, variants)
+}
+
+func (g *sourceGenerator) writeFields(st *types.Struct) ([]*tsMember, []*tsType) {
+	members := []*tsMember{}
+	extends := []*tsType{}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		// fields of unexported embedded struct are promoted (as encoding/json and goja do)
+		if _, isStruct := f.Type().Underlying().(*types.Struct); !f.Exported() && !(f.Embedded() && isStruct) {
+			continue
+		}
+		tag := reflect.StructTag(st.Tag(i))
+		jsonInfo := parseJSONTag(f.Name(), tag, isScalarType(f.Type()))
+		field := reflect.StructField{Name: f.Name(), Type: sourcePlaceholderType, Tag: tag, Index: []int{i}, Anonymous: f.Embedded()}
+		if !f.Exported() {
+			field.PkgPath = f.Pkg().Path()
+		}
+		jsonInfo = g.mapFieldInfo(sourcePlaceholderType, field, jsonInfo)
+		if jsonInfo.Skip {
+			continue
+		}
+		if f.Embedded() && (jsonInfo.Name == "" || jsonInfo.Promoted) {
+			extends = append(extends, g.getTypingNameForEmbedded(f.Type()))
+			if jsonInfo.Name == "" {
+				continue
+			}
+		}
+
+		member := &tsMember{
+			Name:     jsonInfo.name(),
+			Doc:      g.docs[f.Pos()],
+			Optional: jsonInfo.Optional,
+		}
+		leave := g.enter("." + f.Name())
+		if jsonInfo.AsString {
+			member.Type = tsKeywordType("string")
+			if _, isPointer := f.Type().(*types.Pointer); isPointer {
+				member.Type = tsNullableType(member.Type)
+			}
+		} else {
+			member.Type = g.getTypingName(f.Type())
+		}
+		if member.Optional {
+			member.Type = getOmitEmptyType(member.Type, getTypeKind(f.Type()))
+		}
+		leave()
+		members = append(members, member)
+	}
+	return members, extends
+}
+
+func (g *sourceGenerator) writeMethods(methods *types.MethodSet) []*tsMember {
+	members := []*tsMember{}
+	funcs := []*types.Func{}
+	for i := 0; i < methods.Len(); i++ {
+		if f, ok := methods.At(i).Obj().(*types.Func); ok && f.Exported() {
+			funcs = append(funcs, f)
+		}
+	}
+	slices.SortFunc(funcs, func(a, b *types.Func) int { return strings.Compare(a.Name(), b.Name()) })
+
+	for _, f := range funcs {
+		name := g.getMethodName(sourcePlaceholderType, reflect.Method{Name: f.Name()})
+		if name == "" {
+			continue
+		}
+		leave := g.enter("." + f.Name() + "()")
+		fn := g.getSignature(f.Type().(*types.Signature))
+		if fn == nil {
+			g.report("method with multiple results")
+			leave()
+			members = append(members, &tsMember{Comment: fmt.Sprintf("multiple results %s", f.Name())})
+			continue
+		}
+		leave()
+
+		members = append(members, &tsMember{
+			Name:     name,
+			Doc:      g.docs[f.Pos()],
+			IsMethod: true,
+			Params:   fn.Params,
+			Result:   fn.Result,
+			Throws:   fn.Throws,
+		})
+	}
+	return members
+}
+
+// Returns function type of signature, nil if results can't be written.
+func (g *sourceGenerator) getSignature(sig *types.Signature) *tsType {
+	params := []*tsParam{}
+	for pI := 0; pI < sig.Params().Len(); pI++ {
+		p := sig.Params().At(pI)
+		name := p.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("p%d", pI+1)
+		}
+		params = append(params, &tsParam{
+			Name:     name,
+			Type:     g.getTypingName(p.Type()),
+			Variadic: sig.Variadic() && pI == sig.Params().Len()-1,
+		})
+	}
+	lastIsError := sig.Results().Len() > 0 && isErrorType(sig.Results().At(sig.Results().Len()-1).Type())
+	results := []*tsType{}
+	for rI := 0; rI < sig.Results().Len(); rI++ {
+		if rI == sig.Results().Len()-1 && lastIsError && g.throwsErrors() {
+			// thrown, not written
+			results = append(results, nil)
+			break
+		}
+		results = append(results, g.getTypingName(sig.Results().At(rI).Type()))
+	}
+	return g.getFuncType(params, results, lastIsError)
+}
+
+func (g *sourceGenerator) getTypingName(t types.Type) *tsType {
+	switch t := types.Unalias(t).(type) {
+	case *types.Basic:
+		if t.Kind() == types.UnsafePointer {
+			return g.getTypingNameForUnsupported(t, reflect.UnsafePointer)
+		}
+		return tsKeywordType(getTypingNameForBasic(t))
+	case *types.Signature:
+		if !g.includesFuncs() {
+			return g.getTypingNameForUnsupported(t, reflect.Func)
+		}
+		if fn := g.getSignature(t); fn != nil {
+			return fn
+		}
+		return g.getTypingNameForUnsupported(t, reflect.Func)
+	case *types.Chan:
+		return g.getTypingNameForUnsupported(t, reflect.Chan)
+	case *types.Pointer:
+		return tsNullableType(g.getTypingName(t.Elem()))
+	case *types.Slice:
+		if isByteType(t.Elem()) {
+			return g.getBytesType("")
+		}
+		defer g.enter("[]")()
+		return g.getSliceType(g.getTypingName(t.Elem()))
+	case *types.Array:
+		defer g.enter("[]")()
+		return g.getArrayType(g.getTypingName(t.Elem()), int(t.Len()))
+	case *types.Map:
+		leave := g.enter("[]")
+		elem := g.getTypingName(t.Elem())
+		leave()
+		return g.getTypingNameForMap(t.Key(), elem)
+	case *types.TypeParam:
+		return tsTypeParamType(t.Obj().Name())
+	case *types.Interface:
+		if t.Empty() {
+			return tsKeywordType("any")
+		}
+		g.report("unsupported anonymous interface %s", t)
+		return tsKeywordType("unknown")
+	case *types.Struct:
+		members, extends := g.writeFields(t)
+		return &tsType{Kind: tsObject, Members: members, Extends: extends}
+	case *types.Named:
+		if tsType, isMapped := g.getMappedType(t); isMapped {
+			return tsKeywordType(tsType)
+		}
+		g.reach(getNamedTypeID(t))
+		switch u := t.Underlying().(type) {
+		case *types.Basic:
+			// aliases from packages which are not generated are written as base type
+			if t.Obj().Pkg() == nil || (!g.includesPackage(t.Obj().Pkg().Path()) && !g.excluded[getNamedTypeID(t)]) {
+				return tsKeywordType(getTypingNameForBasic(u))
+			}
+			g.typesToProcess = append(g.typesToProcess, t)
+			return tsRefType(getNamedTypeID(t), t.Obj().Name())
+		case *types.Interface:
+			if t.Obj().Pkg() == nil && u.Empty() {
+				return tsKeywordType("any")
+			}
+			if !g.shouldWriteType(t) && !g.excluded[getNamedTypeID(t)] {
+				pkgPath := ""
+				if t.Obj().Pkg() != nil {
+					pkgPath = t.Obj().Pkg().Path()
+				}
+				g.reportNotGenerated(t.String(), t.Obj().Name(), pkgPath)
+				return tsKeywordType("unknown")
+			}
+			g.typesToProcess = append(g.typesToProcess, t)
+			return tsNullableType(tsRefType(getNamedTypeID(t), t.Obj().Name()))
+		case *types.Struct:
+			if !g.shouldWriteType(t) && !g.excluded[getNamedTypeID(t)] {
+				if !g.includesPackage(t.Obj().Pkg().Path()) {
+					g.report("type %s from package which is not generated", t)
+				}
+				return tsKeywordType("unknown")
+			}
+			g.typesToProcess = append(g.typesToProcess, t)
+			return g.getTypingNameForNamed(t)
+		case *types.Slice:
+			if isByteType(u.Elem()) {
+				return g.getBytesType(getNamedTypeID(t))
+			}
+			return g.getTypingName(u)
+		default:
+			return g.getTypingName(u)
+		}
+	}
+	return tsKeywordType("unknown")
+}
+
+// Returns type of map with given key type, reports error if key can't be represented.
+func (g *sourceGenerator) getTypingNameForMap(key types.Type, elem *tsType) *tsType {
+	kind := getTypeKind(key)
+	if named, ok := types.Unalias(key).(*types.Named); ok && len(g.valuesOf(named)) > 0 && g.isEnumKey(kind) {
+		return g.getMapType(g.getTypingName(key), elem, true)
+	}
+	keyType := g.getMapKeyType(kind, isTextMarshaler(key))
+	if keyType == nil {
+		g.report("unsupported map key type %s", key)
+		return tsKeywordType("unknown")
+	}
+	return g.getMapType(keyType, elem, false)
+}
+
+// Returns type mapped by configuration for type not supported in typescript (eg. chan), reports error if kind is not mapped.
+func (g *sourceGenerator) getTypingNameForUnsupported(t types.Type, kind reflect.Kind) *tsType {
+	if tsType, ok := g.kinds[kind]; ok {
+		return tsKeywordType(tsType)
+	}
+	g.report("unsupported type %s", t)
+	return tsKeywordType("unknown")
+}
+
+func (g *sourceGenerator) getTypingNameForNamed(t *types.Named) *tsType {
+	args := []*tsType{}
+	for i := 0; i < t.TypeArgs().Len(); i++ {
+		args = append(args, g.getTypingName(t.TypeArgs().At(i)))
+	}
+	return tsRefType(getNamedTypeID(t), t.Obj().Name(), args...)
+}
+
+func (g *sourceGenerator) getTypingNameForEmbedded(t types.Type) *tsType {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		g.typesToProcess = append(g.typesToProcess, named)
+		return g.getTypingNameForNamed(named)
+	}
+	return g.getTypingName(t)
+}
+
+func getTypingNameForBasic(t *types.Basic) string {
+	switch {
+	case t.Info()&types.IsBoolean != 0:
+		return "boolean"
+	case t.Info()&types.IsString != 0:
+		return "string"
+	case t.Kind() == types.Uintptr, t.Info()&types.IsComplex != 0:
+		return "object"
+	case t.Info()&types.IsNumeric != 0:
+		return "number"
+	}
+	return "unknown"
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func isByteType(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+// Returns reflect kind of string, integer, slice, map and pointer types, reflect.Invalid for others.
+func getTypeKind(t types.Type) reflect.Kind {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if u.Info()&types.IsString != 0 {
+			return reflect.String
+		}
+		if u.Info()&types.IsInteger != 0 {
+			return reflect.Int
+		}
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Map:
+		return reflect.Map
+	case *types.Pointer:
+		return reflect.Pointer
+	}
+	return reflect.Invalid
+}
+
+// Checks if value of type implements encoding.TextMarshaler.
+func isTextMarshaler(t types.Type) bool {
+	sel := types.NewMethodSet(t).Lookup(nil, "MarshalText")
+	if sel == nil {
+		return false
+	}
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 2
+}
+
+// Checks if encoding/json can apply ",string" option to field of given type.
+func isScalarType(t types.Type) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	return b.Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0
+}
+
+// Returns typescript literal for constant value.
+func getValueLiteral(v constant.Value) string {
+	switch v.Kind() {
+	case constant.String:
+		return strconv.Quote(constant.StringVal(v))
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	default:
+		return v.ExactString()
+	}
+}
+
+// Returns name of value in const object (without prefix of type name, eg. StatusActive -> Active).
+func getValueName(typeName string, name string) string {
+	if n, ok := strings.CutPrefix(name, typeName); ok && isValidIdentifier(n) {
+		return n
+	}
+	return name
+}
@@ -0,0 +1,195 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+const testModelsPkg = "github.com/michal-laskowski/wax-libs/gots/testdata/models"
+
+func Test_FromSource(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateTypeDefinitionFromSource(buf, "", testModelsPkg, []string{"./testdata/models"}, "User", "Notifier")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/**
+ * User account.
+ *
+ * Used by views.
+ */
+type User = {
+  ID: number
+  Status: Status
+  Role: Role
+  Friends: (null | User)[]
+  Contacts: Page<Contact>
+  /** DisplayName returns name to display. */
+  DisplayName(separator: string, ...parts: string[]): string
+} & Contact
+
+/** Notifier sends notifications. */
+type Notifier = {
+  /** Notify sends message to user. */
+  Notify(user: null | User, message: string): boolean
+}
+
+/** Contact of user. */
+type Contact = {
+  /** Name to display. */
+  name: string
+  /** Email address. */
+  email?: string
+}
+
+/** Status of user account. */
+type Status = "active" | "disabled" | "unknown"
+declare const Status: {
+  /** StatusActive is status of active account. */
+  readonly Active: "active"
+  /** Account was disabled. */
+  readonly Disabled: "disabled"
+}
+
+/** Role of user. */
+type Role = 1 | 2
+
+/** Page of items. */
+type Page<T> = {
+  /** Items on page. */
+  Items: T[]
+  Total: number
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_FromSourceMarkedTypes(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateTypeDefinitionFromSource(buf, "", testModelsPkg, []string{"./testdata/models"})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	actual := buf.String()
+	if !strings.HasPrefix(actual, "/**\n * User account.") {
+		t.Errorf("Expected marked type first, got:\n%v", actual)
+	}
+	if strings.Contains(actual, "type Notifier") {
+		t.Errorf("Expected only marked types and its dependencies, got:\n%v", actual)
+	}
+}
+
+func Test_FromSourceMaps(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(testModelsPkg)).
+		GenerateFromSource(buf, []string{"./testdata/models"}, "Stats")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** Stats of accounts. */
+type Stats = {
+  ByStatus: { [K in Status]?: number }
+  ByRole: { [K in Role]?: number }
+  ByDay: Record<number, number>
+  /**
+   * Top returns most common status and number of accounts with it.
+   *
+   * @throws error returned by go function
+   */
+  Top(): [Status, number]
+}
+
+/** Status of user account. */
+type Status = "active" | "disabled" | "unknown"
+declare const Status: {
+  /** StatusActive is status of active account. */
+  readonly Active: "active"
+  /** Account was disabled. */
+  readonly Disabled: "disabled"
+}
+
+/** Role of user. */
+type Role = 1 | 2
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_FromSourceSingleConst(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(testModelsPkg), gots.WithRuntime(gots.RuntimeJSON)).
+		GenerateFromSource(buf, []string{"./testdata/models"}, "Level", "Priority")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** Level of logging. */
+type Level = number
+
+/** Priority of task. */
+type Priority = 10
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_FromSourceMappedWithValues(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateTypeDefinitionFromSource(buf, "", "", []string{"./testdata/models"}, "Schedule")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** Schedule of reports. */
+type Schedule = {
+  Every: number
+  Month: number
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_FromSourceUnexportedEmbedded(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateTypeDefinitionFromSource(buf, "", testModelsPkg, []string{"./testdata/models"}, "Order")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** Order of user. */
+type Order = {
+  Number: string
+} & audit
+
+type audit = {
+  CreatedBy: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
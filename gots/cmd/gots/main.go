@@ -0,0 +1,230 @@
+// Command gots generates typescript typings (.d.ts) for Go types.
+//
+// Usage:
+//
+//	gots [flags] [packages]
+//
+// Packages default to current directory. Without -types generates types marked with //gots:export directive
+// (or all exported types if none is marked).
+//
+// With -split writes one ES module per package into -out directory.
+//
+// Output starts with "Code generated by gots; DO NOT EDIT." header with hash of generated types,
+// files with unchanged content are not rewritten.
+//
+// Use it with go:generate:
+//
+//	//go:generate go run github.com/michal-laskowski/wax-libs/gots/cmd/gots -out ../web/types/models.d.ts .
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+func main() {
+	typeNames := flag.String("types", "", "comma separated names of root types (can be qualified with package path)")
+	namespace := flag.String("namespace", "", "namespace wrapping generated types")
+	pkgFilter := flag.String("filter", "", "generate types in packages with given prefix (defaults to module path)")
+	out := flag.String("out", "", "output file (defaults to stdout)")
+	export := flag.Bool("export", false, "write declarations with export keyword")
+	interfaces := flag.Bool("interfaces", false, "write object types as interfaces")
+	packageNamespaces := flag.Bool("package-namespaces", false, "write types in namespace per package")
+	module := flag.Bool("module", false, "write output as ES module (export type)")
+	split := flag.Bool("split", false, "write one ES module per package into -out directory")
+	guards := flag.String("guards", "", "output file of runtime type guards module (.ts)")
+	runtime := flag.String("runtime", "goja", "runtime in which values are used: goja or json")
+	excluded := flag.String("exclude", "", "comma separated names of excluded types qualified with package path")
+	order := flag.String("order", "reached", "order of declarations: reached, alphabetical or roots (roots first, then dependencies)")
+	strict := flag.Bool("strict", false, "fail when some types can't be represented (they are written as unknown with warning otherwise)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: gots [flags] [packages]\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	opts := []gots.Option{gots.WithNamespace(*namespace), gots.WithExcludedNames(splitList(*excluded)...), gots.WithGeneratedHeader()}
+	if *export {
+		opts = append(opts, gots.WithExport())
+	}
+	if *interfaces {
+		opts = append(opts, gots.WithInterfaces())
+	}
+	if *packageNamespaces {
+		opts = append(opts, gots.WithPackageNamespaces())
+	}
+	if *module {
+		opts = append(opts, gots.WithModule())
+	}
+	if *strict {
+		opts = append(opts, gots.WithStrict())
+	}
+	switch *runtime {
+	case "goja":
+	case "json":
+		opts = append(opts, gots.WithRuntime(gots.RuntimeJSON))
+	default:
+		fmt.Fprintf(os.Stderr, "gots: unknown runtime %s\n", *runtime)
+		os.Exit(2)
+	}
+	switch *order {
+	case "reached":
+	case "alphabetical":
+		opts = append(opts, gots.WithOrder(gots.OrderAlphabetical))
+	case "roots":
+		opts = append(opts, gots.WithOrder(gots.OrderRootsFirst))
+	default:
+		fmt.Fprintf(os.Stderr, "gots: unknown order %s\n", *order)
+		os.Exit(2)
+	}
+
+	cfg := config{
+		patterns:  flag.Args(),
+		typeNames: splitList(*typeNames),
+		pkgFilter: *pkgFilter,
+		out:       *out,
+		module:    *module,
+		split:     *split,
+		guards:    *guards,
+		strict:    *strict,
+		opts:      opts,
+	}
+	if err := run(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "gots: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	patterns  []string
+	typeNames []string
+	pkgFilter string
+	out       string
+	module    bool
+	split     bool
+	guards    string
+	strict    bool
+	opts      []gots.Option
+}
+
+func run(cfg config) error {
+	if len(cfg.patterns) == 0 {
+		cfg.patterns = []string{"."}
+	}
+	if cfg.pkgFilter == "" {
+		modulePath, err := getModulePath(cfg.patterns)
+		if err != nil {
+			return err
+		}
+		cfg.pkgFilter = modulePath
+	}
+	g := gots.NewGenerator(append(cfg.opts, gots.WithPackages(cfg.pkgFilter))...)
+	if !cfg.strict {
+		defer warnDiagnostics(g)
+	}
+
+	if cfg.guards != "" {
+		if err := writeGuards(g, cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.split {
+		return writeModules(g, cfg)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := g.GenerateFromSource(buf, cfg.patterns, cfg.typeNames...); err != nil {
+		return err
+	}
+	if cfg.out == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return writeFile(cfg.out, buf.Bytes())
+}
+
+func writeModules(g *gots.Generator, cfg config) error {
+	if cfg.out == "" {
+		return errors.New("-split requires -out directory")
+	}
+	modules, err := g.GenerateModulesFromSource(cfg.patterns, cfg.typeNames...)
+	if err != nil {
+		return err
+	}
+	for name, content := range modules {
+		if err := writeFile(filepath.Join(cfg.out, name), []byte(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes guards module, types are imported from -out module (global typings are used otherwise).
+func writeGuards(g *gots.Generator, cfg config) error {
+	typesImport := ""
+	if cfg.out != "" && cfg.module {
+		rel, err := filepath.Rel(filepath.Dir(cfg.guards), cfg.out)
+		if err != nil {
+			return err
+		}
+		typesImport = strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(rel), ".ts"), ".d")
+		if !strings.HasPrefix(typesImport, ".") {
+			typesImport = "./" + typesImport
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := g.GenerateGuardsFromSource(buf, typesImport, cfg.patterns, cfg.typeNames...); err != nil {
+		return err
+	}
+	return writeFile(cfg.guards, buf.Bytes())
+}
+
+// Prints diagnostics of last generation as warnings.
+func warnDiagnostics(g *gots.Generator) {
+	for _, d := range g.Diagnostics() {
+		fmt.Fprintf(os.Stderr, "gots: warning: %s\n", d)
+	}
+}
+
+// Writes file, file with the same content is not rewritten (its modification time is kept).
+func writeFile(name string, data []byte) error {
+	if existing, err := os.ReadFile(name); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
+
+func splitList(v string) []string {
+	result := []string{}
+	for _, n := range strings.Split(v, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func getModulePath(patterns []string) (string, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedModule}, patterns...)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range pkgs {
+		if p.Module != nil {
+			return p.Module.Path, nil
+		}
+	}
+	return "", nil
+}
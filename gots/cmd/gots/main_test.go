@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_WriteFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "types", "models.d.ts")
+	assertContent := func(expected string) {
+		t.Helper()
+		actual, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(actual) != expected {
+			t.Errorf("Content not as expected:\n%s", actual)
+		}
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := writeFile(name, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generated := "// Code generated by gots; DO NOT EDIT.\n// Hash: 1a2b\n\ntype Contact = {}\n"
+	write(generated)
+	assertContent(generated)
+
+	// the same content, file is not rewritten
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(name, past, past); err != nil {
+		t.Fatal(err)
+	}
+	write(generated)
+	if info, err := os.Stat(name); err != nil {
+		t.Fatal(err)
+	} else if !info.ModTime().Equal(past) {
+		t.Errorf("File rewritten, modified at %v", info.ModTime())
+	}
+
+	// the same hash with different body (eg. other version of generator) is written
+	sameHash := "// Code generated by gots; DO NOT EDIT.\n// Hash: 1a2b\n\ntype Contact = { Name: string }\n"
+	write(sameHash)
+	assertContent(sameHash)
+
+	changed := "// Code generated by gots; DO NOT EDIT.\n// Hash: 3c4d\n\ntype Contact = { Name: string }\n"
+	write(changed)
+	assertContent(changed)
+
+	// content without hash is written too
+	plain := "type Contact = { Name: string }\n"
+	write(plain)
+	assertContent(plain)
+	write(changed)
+	assertContent(changed)
+}
+
+func Test_Run(t *testing.T) {
+	dir := t.TempDir()
+	generate := func(cfg config) string {
+		t.Helper()
+		cfg.out = filepath.Join(dir, "models.d.ts")
+		if err := run(cfg); err != nil {
+			t.Fatalf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+		}
+		content, err := os.ReadFile(cfg.out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+	assertTypes := func(content string, expected ...string) {
+		t.Helper()
+		actual := []string{}
+		for _, line := range strings.Split(content, "\n") {
+			if name, ok := strings.CutPrefix(line, "type "); ok {
+				actual = append(actual, strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '<' })[0])
+			}
+		}
+		if !slices.Equal(actual, expected) {
+			t.Errorf("Types not as expected:\n%v\nexpected:\n%v", actual, expected)
+		}
+	}
+
+	// types marked with //gots:export, filter defaults to module path
+	assertTypes(generate(config{patterns: []string{"../../testdata/models"}}), "User", "Contact", "Status", "Role", "Page")
+
+	// -types
+	assertTypes(generate(config{patterns: []string{"../../testdata/models"}, typeNames: []string{"Stats", "Schedule"}}), "Stats", "Schedule", "Status", "Role")
+
+	// all exported types if none is marked, types from other packages of module are generated too
+	assertTypes(generate(config{patterns: []string{"../../testdata/billing"}}),
+		"Invoice", "BillingAddress", "Shipment", "Payment", "Contact", "Status", "ShippingAddress", "ShippingMethod")
+
+	// types from packages outside of -filter are not generated (no conflict of Address names)
+	assertTypes(generate(config{patterns: []string{"../../testdata/billing"}, pkgFilter: "github.com/michal-laskowski/wax-libs/gots/testdata/billing"}),
+		"Invoice", "Address", "Shipment", "Payment")
+
+	// -split
+	out := filepath.Join(dir, "split")
+	if err := run(config{patterns: []string{"../../testdata/billing"}, out: out, split: true}); err != nil {
+		t.Fatalf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []string{}
+	for _, e := range entries {
+		files = append(files, e.Name())
+	}
+	if expected := []string{"billing.d.ts", "models.d.ts", "shipping.d.ts"}; !slices.Equal(files, expected) {
+		t.Errorf("Modules not as expected:\n%v", files)
+	}
+	billing, err := os.ReadFile(filepath.Join(out, "billing.d.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(billing), "import type { Contact, Status } from \"./models\"\nimport type { ShippingAddress, ShippingMethod } from \"./shipping\"\n") {
+		t.Errorf("Module not as expected:\n%s", billing)
+	}
+	if err := run(config{patterns: []string{"../../testdata/billing"}, split: true}); err == nil {
+		t.Errorf("-split without -out should fail")
+	}
+}
@@ -0,0 +1,111 @@
+package gots
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagnostic describes part of go type which can't be represented in typescript.
+type Diagnostic struct {
+	// Path of go type by which part was reached (eg. Order.Items[].Product.Price).
+	Path string
+	// Why part can't be represented.
+	Reason string
+}
+
+func (d Diagnostic) String() string {
+	return d.Path + ": " + d.Reason
+}
+
+// DiagnosticsError is returned in strict mode when some types can't be represented.
+type DiagnosticsError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *DiagnosticsError) Error() string {
+	lines := []string{}
+	for _, d := range e.Diagnostics {
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Enables strict mode: generating fails with *DiagnosticsError when some types can't be represented.
+//
+// Without it generator writes what it can (unsupported types as unknown), see Generator.Diagnostics.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// Returns diagnostics of last generation.
+func (g *Generator) Diagnostics() []Diagnostic {
+	return g.reported
+}
+
+// diagnostics collects diagnostics with path of currently written type.
+type diagnostics struct {
+	// path of currently written part of type
+	path string
+	// paths by which types were reached first, by type ID
+	paths map[string]string
+	list  []Diagnostic
+}
+
+// Starts writing type with given ID, path is the one by which type was reached first.
+func (d *diagnostics) start(id string, name string) {
+	d.path = name
+	if p, ok := d.paths[id]; ok {
+		d.path = p
+	}
+}
+
+// Registers type with given ID as root, its path is its name.
+func (d *diagnostics) root(id string, name string) {
+	d.reach(id)
+	d.paths[id] = name
+}
+
+// Records path by which type with given ID is reached (only first one is kept).
+func (d *diagnostics) reach(id string) {
+	if d.paths == nil {
+		d.paths = map[string]string{}
+	}
+	if _, ok := d.paths[id]; !ok {
+		d.paths[id] = d.path
+	}
+}
+
+// Appends elem to path, returns function restoring it.
+func (d *diagnostics) enter(elem string) func() {
+	prev := d.path
+	d.path += elem
+	return func() {
+		d.path = prev
+	}
+}
+
+func (d *diagnostics) report(format string, args ...any) {
+	d.list = append(d.list, Diagnostic{Path: d.path, Reason: fmt.Sprintf(format, args...)})
+}
+
+// Reports reference to type which is not generated (predeclared or from package which is not generated).
+func (d *diagnostics) reportNotGenerated(typeName string, name string, pkgPath string) {
+	switch {
+	case name == "":
+		d.report("unsupported anonymous interface %s", typeName)
+	case pkgPath == "":
+		d.report("predeclared type %s is not supported", typeName)
+	default:
+		d.report("type %s from package which is not generated", typeName)
+	}
+}
+
+// Returns error if generating should fail because of diagnostics.
+func (o *options) diagnosticsError(list []Diagnostic) error {
+	if !o.strict || len(list) == 0 {
+		return nil
+	}
+	return &DiagnosticsError{Diagnostics: list}
+}
@@ -0,0 +1,196 @@
+package gots_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type DiagOrder struct {
+	Items []DiagItem
+}
+
+func (o DiagOrder) Totals() (float64, float64) {
+	return 0, 0
+}
+
+type DiagItem struct {
+	Product DiagProduct
+}
+
+type DiagProduct struct {
+	Name    string
+	Price   func() (int, int)
+	Updates map[string]chan int
+}
+
+func Test_Diagnostics(t *testing.T) {
+	expectedDiagnostics := []gots.Diagnostic{
+		{Path: "DiagOrder.Items[].Product.Updates[]", Reason: "unsupported type chan int"},
+	}
+	buf := bytes.NewBufferString("")
+
+	g := gots.NewGenerator(gots.WithPackages(thisPackageOnly())).Add(DiagOrder{})
+	err := g.Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DiagOrder = {
+  Items: DiagItem[]
+  Totals(): [number, number]
+}
+
+type DiagItem = {
+  Product: DiagProduct
+}
+
+type DiagProduct = {
+  Name: string
+  Price: () => [number, number]
+  Updates: Record<string, unknown>
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+	if d := sortedDiagnostics(g.Diagnostics()); !slices.Equal(d, expectedDiagnostics) {
+		t.Errorf("Diagnostics not as expected: %v", d)
+	}
+
+	expectedDiagnostics = []gots.Diagnostic{
+		{Path: "DiagOrder.Items[].Product.Price", Reason: "unsupported type func() (int, int)"},
+		{Path: "DiagOrder.Items[].Product.Updates[]", Reason: "unsupported type chan int"},
+		{Path: "DiagOrder.Totals()", Reason: "method with multiple results"},
+	}
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithStrict(), gots.WithFuncResults(gots.FuncResultsSingle)).
+		Add(DiagOrder{}).
+		Generate(buf)
+	var diagErr *gots.DiagnosticsError
+	if !errors.As(err, &diagErr) {
+		t.Fatalf("Expected diagnostics error, got %v", err)
+	}
+	if d := sortedDiagnostics(diagErr.Diagnostics); !slices.Equal(d, expectedDiagnostics) {
+		t.Errorf("Diagnostics not as expected: %v", d)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output in strict mode, got:\n%s", buf.String())
+	}
+}
+
+type DiagHandler struct {
+	Name   string       `json:"name"`
+	OnSave func(string) `json:"onSave"`
+	Hidden func()       `json:"-"`
+	Events chan int     `json:"-"`
+}
+
+func Test_DiagnosticsFuncsInJSON(t *testing.T) {
+	g := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON)).Add(DiagHandler{})
+	err := g.Generate(bytes.NewBufferString(""))
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expectedDiagnostics := []gots.Diagnostic{
+		{Path: "DiagHandler.OnSave", Reason: "unsupported type func(string)"},
+	}
+	if d := sortedDiagnostics(g.Diagnostics()); !slices.Equal(d, expectedDiagnostics) {
+		t.Errorf("Diagnostics not as expected: %v", d)
+	}
+
+	g = gots.NewGenerator(gots.WithPackages(thisPackageOnly())).Add(DiagHandler{})
+	err = g.Generate(bytes.NewBufferString(""))
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	if d := g.Diagnostics(); len(d) > 0 {
+		t.Errorf("Expected no diagnostics in goja runtime, got: %v", d)
+	}
+}
+
+type DiagPayment struct {
+	Err   error
+	Body  io.Reader
+	State http.ConnState
+}
+
+func (p DiagPayment) Save() error {
+	return nil
+}
+
+func Test_DiagnosticsNotGenerated(t *testing.T) {
+	expectedDiagnostics := []gots.Diagnostic{
+		{Path: "DiagPayment.Body", Reason: "type io.Reader from package which is not generated"},
+		{Path: "DiagPayment.Err", Reason: "predeclared type error is not supported"},
+	}
+	buf := bytes.NewBufferString("")
+
+	g := gots.NewGenerator(gots.WithPackages(thisPackageOnly())).Add(DiagPayment{})
+	err := g.Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DiagPayment = {
+  Err: unknown
+  Body: unknown
+  State: number
+  /** @throws error returned by go function */
+  Save(): void
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+	if d := sortedDiagnostics(g.Diagnostics()); !slices.Equal(d, expectedDiagnostics) {
+		t.Errorf("Diagnostics not as expected: %v", d)
+	}
+
+	g = gots.NewGenerator(gots.WithPackages("github.com/michal-laskowski/wax-libs/gots/testdata/billing"), gots.WithStrict())
+	err = g.GenerateFromSource(bytes.NewBufferString(""), []string{"./testdata/billing"}, "Payment")
+	var diagErr *gots.DiagnosticsError
+	if !errors.As(err, &diagErr) {
+		t.Fatalf("Expected diagnostics error, got %v", err)
+	}
+	expectedDiagnostics = []gots.Diagnostic{
+		{Path: "Payment.Body", Reason: "type io.Reader from package which is not generated"},
+		{Path: "Payment.Err", Reason: "predeclared type error is not supported"},
+	}
+	if d := sortedDiagnostics(diagErr.Diagnostics); !slices.Equal(d, expectedDiagnostics) {
+		t.Errorf("Diagnostics not as expected: %v", d)
+	}
+}
+
+func Test_DiagnosticsFromSource(t *testing.T) {
+	g := gots.NewGenerator(gots.WithPackages("github.com/michal-laskowski/wax-libs/gots/testdata/billing"), gots.WithStrict())
+	err := g.GenerateFromSource(bytes.NewBufferString(""), []string{"./testdata/billing"}, "Invoice")
+	var diagErr *gots.DiagnosticsError
+	if !errors.As(err, &diagErr) {
+		t.Fatalf("Expected diagnostics error, got %v", err)
+	}
+	expected := []gots.Diagnostic{
+		{Path: "Invoice.Customer", Reason: "type github.com/michal-laskowski/wax-libs/gots/testdata/models.Contact from package which is not generated"},
+		{Path: "Invoice.Shipping.Address", Reason: "type github.com/michal-laskowski/wax-libs/gots/testdata/shipping.Address from package which is not generated"},
+		{Path: "Invoice.Shipping.Method", Reason: "type github.com/michal-laskowski/wax-libs/gots/testdata/shipping.Method from package which is not generated"},
+	}
+	if d := sortedDiagnostics(diagErr.Diagnostics); !slices.Equal(d, expected) {
+		t.Errorf("Diagnostics not as expected: %v", d)
+	}
+}
+
+func sortedDiagnostics(d []gots.Diagnostic) []gots.Diagnostic {
+	return slices.SortedFunc(slices.Values(d), func(a, b gots.Diagnostic) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+}
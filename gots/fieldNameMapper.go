@@ -0,0 +1,63 @@
+package gots
+
+import "reflect"
+
+// FieldNameMapper maps go fields and methods to names visible in runtime.
+//
+// It has the same methods as goja.FieldNameMapper, so mapper installed in goja runtime can be passed directly:
+//
+//	gots.WithFieldNameMapper(goja.TagFieldNameMapper("json", true))
+type FieldNameMapper interface {
+	// Returns name of field in runtime, empty if field is not visible.
+	FieldName(t reflect.Type, f reflect.StructField) string
+	// Returns name of method in runtime, empty if method is not visible.
+	MethodName(t reflect.Type, m reflect.Method) string
+}
+
+// NameMapperFunc maps names of fields and methods with the same function (eg. uncapitalizing first letter).
+type NameMapperFunc func(name string) string
+
+func (f NameMapperFunc) FieldName(_ reflect.Type, field reflect.StructField) string {
+	return f(field.Name)
+}
+
+func (f NameMapperFunc) MethodName(_ reflect.Type, m reflect.Method) string {
+	return f(m.Name)
+}
+
+// Names fields and methods by given mapper (as goja.Runtime.SetFieldNameMapper does), json tags are not used then.
+//
+// Source based generator doesn't know reflect types, it passes placeholder type (struct{}) as type of struct and field, field has name, tag and index and method has name only.
+func WithFieldNameMapper(mapper FieldNameMapper) Option {
+	return func(o *options) {
+		o.fieldNameMapper = mapper
+	}
+}
+
+// Returns field info as seen in runtime, with field name mapper field is named by mapper instead of json tag.
+func (o *options) mapFieldInfo(t reflect.Type, f reflect.StructField, info jsonFieldInfo) jsonFieldInfo {
+	if o.fieldNameMapper == nil {
+		return info
+	}
+	name := o.fieldNameMapper.FieldName(t, f)
+	if f.Anonymous {
+		if !f.IsExported() {
+			// goja doesn't expose unexported embedded struct itself
+			name = ""
+		}
+		// fields of embedded struct are promoted, goja exposes also embedded struct under mapped name
+		return jsonFieldInfo{Name: name, FieldName: f.Name, Promoted: true}
+	}
+	return jsonFieldInfo{Name: name, FieldName: f.Name, Skip: name == ""}
+}
+
+// Placeholder of types passed to field name mapper by source based generator.
+var sourcePlaceholderType = reflect.TypeFor[struct{}]()
+
+// Returns name of method in runtime, empty if method is not visible.
+func (o *options) getMethodName(t reflect.Type, m reflect.Method) string {
+	if o.fieldNameMapper == nil {
+		return m.Name
+	}
+	return o.fieldNameMapper.MethodName(t, m)
+}
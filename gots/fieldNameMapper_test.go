@@ -0,0 +1,193 @@
+package gots_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+// tagFieldNameMapper names fields as goja.TagFieldNameMapper("json", true).
+type tagFieldNameMapper struct{}
+
+func (tagFieldNameMapper) FieldName(_ reflect.Type, f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func (tagFieldNameMapper) MethodName(_ reflect.Type, m reflect.Method) string {
+	return uncapitalize(m.Name)
+}
+
+func uncapitalize(name string) string {
+	return string(unicode.ToLower(rune(name[0]))) + name[1:]
+}
+
+type MappedUser struct {
+	FirstName string `json:"firstName,omitempty"`
+	Secret    string `json:"-"`
+	Age       int
+}
+
+func (u MappedUser) FullName() string {
+	return u.FirstName
+}
+
+func Test_FieldNameMapper(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithFieldNameMapper(tagFieldNameMapper{})).
+		Add(MappedUser{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type MappedUser = {
+  firstName: string
+  fullName(): string
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithFieldNameMapper(gots.NameMapperFunc(uncapitalize))).
+		Add(MappedUser{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+type MappedUser = {
+  firstName: string
+  secret: string
+  age: number
+  fullName(): string
+}
+`
+	actual = buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
+
+func Test_FieldNameMapperFromSource(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(testModelsPkg), gots.WithFieldNameMapper(gots.NameMapperFunc(uncapitalize))).
+		GenerateFromSource(buf, []string{"./testdata/models"}, "Stats")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** Stats of accounts. */
+type Stats = {
+  byStatus: { [K in Status]?: number }
+  byRole: { [K in Role]?: number }
+  byDay: Record<number, number>
+  /**
+   * Top returns most common status and number of accounts with it.
+   *
+   * @throws error returned by go function
+   */
+  top(): [Status, number]
+}
+
+/** Status of user account. */
+type Status = "active" | "disabled" | "unknown"
+declare const Status: {
+  /** StatusActive is status of active account. */
+  readonly Active: "active"
+  /** Account was disabled. */
+  readonly Disabled: "disabled"
+}
+
+/** Role of user. */
+type Role = 1 | 2
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
+
+// kindFieldNameMapper inspects types of struct and field, it skips func fields.
+type kindFieldNameMapper struct{}
+
+func (kindFieldNameMapper) FieldName(t reflect.Type, f reflect.StructField) string {
+	if t.Kind() != reflect.Struct || f.Type.Kind() == reflect.Func {
+		return ""
+	}
+	return uncapitalize(f.Name)
+}
+
+func (kindFieldNameMapper) MethodName(t reflect.Type, m reflect.Method) string {
+	return uncapitalize(m.Name)
+}
+
+type MappedMember struct {
+	MappedUser `json:"user"`
+	Team       string `json:"team"`
+}
+
+func Test_FieldNameMapperEmbedded(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithFieldNameMapper(tagFieldNameMapper{}), gots.WithMethods(false)).
+		Add(MappedMember{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type MappedMember = {
+  user: MappedUser
+  team: string
+} & MappedUser
+
+type MappedUser = {
+  firstName: string
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(testModelsPkg), gots.WithFieldNameMapper(kindFieldNameMapper{})).
+		GenerateFromSource(buf, []string{"./testdata/models"}, "Member")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+/** Member of team. */
+type Member = {
+  contact: Contact
+  team: string
+} & Contact
+
+/** Contact of user. */
+type Contact = {
+  /** Name to display. */
+  name: string
+  /** Email address. */
+  email: string
+}
+`
+	actual = buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
@@ -0,0 +1,96 @@
+package gots
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Order of written declarations.
+type Order int
+
+const (
+	// Order in which types are reached from registered types (default).
+	OrderReached Order = iota
+	// Declarations sorted by name.
+	OrderAlphabetical
+	// Registered (root) types sorted by name, then their dependencies sorted by name.
+	OrderRootsFirst
+)
+
+// Sets order of written declarations (default OrderReached). Sorted output doesn't depend on order of registered types.
+func WithOrder(order Order) Option {
+	return func(o *options) {
+		o.order = order
+	}
+}
+
+// Writes standard header of generated file with hash of generated types:
+//
+//	// Code generated by gots; DO NOT EDIT.
+//	// Hash: 3f2a...
+//
+// Hash doesn't change until generated types (or options) change, see GeneratedHash.
+func WithGeneratedHeader() Option {
+	return func(o *options) {
+		o.generatedHeader = true
+	}
+}
+
+const (
+	generatedComment = "Code generated by gots; DO NOT EDIT."
+	hashPrefix       = "Hash: "
+)
+
+// Returns hash written in header of generated file (see WithGeneratedHeader), false if content has no hash.
+//
+// Tooling can compare it with hash of newly generated content to skip rewriting unchanged files.
+func GeneratedHash(content string) (string, bool) {
+	lines := strings.SplitN(content, "\n", 3)
+	if len(lines) < 2 || lines[0] != "// "+generatedComment {
+		return "", false
+	}
+	return strings.CutPrefix(lines[1], "// "+hashPrefix)
+}
+
+func (o *options) sortDecls(decls []*tsDecl) {
+	byName := func(a, b *tsDecl) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+	}
+	switch o.order {
+	case OrderAlphabetical:
+		slices.SortStableFunc(decls, byName)
+	case OrderRootsFirst:
+		slices.SortStableFunc(decls, func(a, b *tsDecl) int {
+			if a.Root != b.Root {
+				if a.Root {
+					return -1
+				}
+				return 1
+			}
+			return byName(a, b)
+		})
+	}
+}
+
+// Returns lines of header written at the beginning of output.
+func (o *options) headerLines(decls []*tsDecl) []string {
+	if !o.generatedHeader {
+		return o.header
+	}
+	return append([]string{generatedComment, hashPrefix + o.hashDecls(decls)}, o.header...)
+}
+
+// Returns hash of declarations, header lines and options changing how they are written.
+func (o *options) hashDecls(decls []*tsDecl) string {
+	// declarations are plain data, marshaling can't fail
+	data, _ := json.Marshal(decls)
+	h := sha256.New()
+	h.Write(data)
+	fmt.Fprintf(h, "%q %q %d %t %t %t %t %+v %d %d", o.header, o.namespace, o.indentWidth, o.interfaces, o.export, o.module, o.packageNamespaces, o.profile, o.funcResults, o.maxTupleLength)
+	return hex.EncodeToString(h.Sum(nil))
+}
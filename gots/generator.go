@@ -0,0 +1,446 @@
+package gots
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Runtime in which values of generated types are used.
+type Runtime int
+
+const (
+	// Values passed to goja (default). Aliases to base types are objects, methods can be called.
+	RuntimeGoja Runtime = iota
+	// Values serialized with encoding/json. Aliases to base types are plain values, there are no methods.
+	RuntimeJSON
+)
+
+// FuncResults defines how results of go functions and methods are written.
+type FuncResults int
+
+const (
+	// As returned by goja (default): trailing error is thrown (written as @throws), multiple results are returned as tuple [A, B].
+	FuncResultsGoja FuncResults = iota
+	// Only single result is written (error is returned as value), methods with multiple results are skipped, functions are not supported.
+	FuncResultsSingle
+)
+
+// Profile describes how values of go types are seen in runtime. Nil pointers are always null.
+type Profile struct {
+	// Alias to base type (eg. type StringAlias string) is an object, otherwise plain value.
+	AliasObjects bool
+	// Methods of types can be called.
+	Methods bool
+	// Func values can be called, otherwise func fields are unsupported (encoding/json fails on them).
+	Funcs bool
+	// Nil slice is null. Field with omitempty is omitted instead.
+	NullSlices bool
+	// Nil map is null. Field with omitempty is omitted instead.
+	NullMaps bool
+	// Integer map keys are strings, otherwise numbers.
+	StringKeys bool
+	// []byte is base64 string (json.RawMessage is any JSON value), otherwise array of numbers.
+	BytesAsString bool
+	// Values are serialized by their marshalers, types implementing json.Marshaler or encoding.TextMarshaler are mapped by type mapping.
+	Marshalers bool
+}
+
+// Returns profile of runtime.
+//
+// In goja aliases to base types are objects, methods and funcs can be called, slices and maps are wrapped (never null) and []byte is array of numbers.
+// Encoding/json writes aliases as plain values, nil slices and maps as null, integer map keys as strings and []byte as base64 string, it uses marshalers and fails on funcs and chans.
+func (r Runtime) Profile() Profile {
+	if r == RuntimeJSON {
+		return Profile{NullSlices: true, NullMaps: true, StringKeys: true, BytesAsString: true, Marshalers: true}
+	}
+	return Profile{AliasObjects: true, Methods: true, Funcs: true}
+}
+
+// Generator generates typescript typings (.d.ts) for go types.
+//
+// Configure it with options and register types with Add (can be called from many places), then call Generate.
+type Generator struct {
+	options
+	types []any
+	// diagnostics of last generation
+	reported []Diagnostic
+}
+
+type options struct {
+	namespace   string
+	indentWidth int
+	interfaces  bool
+	export      bool
+	header      []string
+	// package prefixes of generated types, empty allows all
+	packages []string
+	// IDs of excluded types
+	excluded map[string]bool
+	methods  bool
+	profile  Profile
+	mapping  *TypeMapping
+	module   bool
+
+	packageNamespaces bool
+	namePrefix        func(pkgPath string, pkgName string) string
+	// explicit names of types by type ID
+	renames map[string]string
+	// typescript types of kinds not supported in typescript
+	kinds map[reflect.Kind]string
+	// arrays longer than this are written as T[] instead of tuple
+	maxTupleLength int
+	strict         bool
+	funcResults    FuncResults
+	order          Order
+	// write standard header of generated file
+	generatedHeader bool
+	fieldNameMapper FieldNameMapper
+}
+
+// Option configures Generator.
+type Option func(*options)
+
+// Creates generator configured with given options.
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
+		options: options{
+			indentWidth:    2,
+			excluded:       map[string]bool{},
+			renames:        map[string]string{},
+			kinds:          map[reflect.Kind]string{},
+			maxTupleLength: 8,
+			methods:        true,
+			mapping:        TypeMappings,
+			profile:        RuntimeGoja.Profile(),
+		},
+	}
+	for _, o := range opts {
+		o(&g.options)
+	}
+	return g
+}
+
+// Wraps types in given namespace.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// Sets number of spaces used for indentation (default 2).
+func WithIndent(width int) Option {
+	return func(o *options) {
+		o.indentWidth = width
+	}
+}
+
+// Writes object types as interfaces (interface X extends Y {}) where possible.
+func WithInterfaces() Option {
+	return func(o *options) {
+		o.interfaces = true
+	}
+}
+
+// Writes declarations with export keyword.
+func WithExport() Option {
+	return func(o *options) {
+		o.export = true
+	}
+}
+
+// Writes output as ES module, all declarations are exported.
+//
+// Use GenerateModules to write one module per go package.
+func WithModule() Option {
+	return func(o *options) {
+		o.module = true
+	}
+}
+
+// Writes given lines as comments at the beginning of output.
+func WithHeader(lines ...string) Option {
+	return func(o *options) {
+		o.header = append(o.header, lines...)
+	}
+}
+
+// Generates only types from packages with one of given prefixes. Without it types from all packages are generated.
+func WithPackages(prefixes ...string) Option {
+	return func(o *options) {
+		o.packages = append(o.packages, prefixes...)
+	}
+}
+
+// Excludes types of given samples. Excluded types are not generated, but references to them are kept (declare them elsewhere).
+func WithExcluded(samples ...any) Option {
+	return func(o *options) {
+		for _, s := range samples {
+			o.excluded[getTypeID(getUnderlyingType(reflect.TypeOf(s)))] = true
+		}
+	}
+}
+
+// Excludes types with given package path and name (eg. "github.com/some/models.Contact").
+func WithExcludedNames(names ...string) Option {
+	return func(o *options) {
+		for _, n := range names {
+			o.excluded[n] = true
+		}
+	}
+}
+
+// Enables or disables writing methods (enabled by default, always disabled if profile has no methods).
+func WithMethods(enabled bool) Option {
+	return func(o *options) {
+		o.methods = enabled
+	}
+}
+
+// Sets runtime in which values are used (default RuntimeGoja), same as WithProfile(runtime.Profile()).
+func WithRuntime(runtime Runtime) Option {
+	return func(o *options) {
+		o.profile = runtime.Profile()
+	}
+}
+
+// Sets profile describing how values are seen in runtime (eg. RuntimeJSON.Profile() with some changes).
+func WithProfile(profile Profile) Option {
+	return func(o *options) {
+		o.profile = profile
+	}
+}
+
+// Maps types of given kind not supported in typescript (eg. reflect.Chan) to typescript type.
+//
+// Generating type with field of unsupported and not mapped kind fails.
+func WithKindMapping(kind reflect.Kind, tsType string) Option {
+	return func(o *options) {
+		o.kinds[kind] = tsType
+	}
+}
+
+// Sets maximal length of go array written as tuple (default 8), longer arrays are written as T[].
+func WithMaxTupleLength(length int) Option {
+	return func(o *options) {
+		o.maxTupleLength = length
+	}
+}
+
+// Sets how results of functions and methods are written (default FuncResultsGoja).
+func WithFuncResults(mode FuncResults) Option {
+	return func(o *options) {
+		o.funcResults = mode
+	}
+}
+
+// Uses given type mapping instead of TypeMappings.
+func WithTypeMapping(mapping *TypeMapping) Option {
+	return func(o *options) {
+		o.mapping = mapping
+	}
+}
+
+// Registers types to generate. Values registered with Enum can be passed too.
+func (g *Generator) Add(typesToGenerate ...any) *Generator {
+	g.types = append(g.types, typesToGenerate...)
+	return g
+}
+
+// Generates typings for registered types using reflection.
+func (g *Generator) Generate(out io.StringWriter) error {
+	decls, err := g.generateDecls()
+	if err != nil {
+		return err
+	}
+	return g.write(out, decls, nil)
+}
+
+// Generates typings for types loaded from source of given packages (see GenerateTypeDefinitionFromSource).
+//
+// Types registered with Add are ignored.
+func (g *Generator) GenerateFromSource(out io.StringWriter, patterns []string, typeNames ...string) error {
+	decls, err := g.generateDeclsFromSource(patterns, typeNames...)
+	if err != nil {
+		return err
+	}
+	return g.write(out, decls, nil)
+}
+
+func (g *Generator) generateDecls() ([]*tsDecl, error) {
+	generator := definitionGenerator{
+		options: &g.options,
+	}
+	decls, err := generator.Generate(g.types...)
+	g.reported = generator.list
+	g.sortDecls(decls)
+	return decls, err
+}
+
+func (g *Generator) generateDeclsFromSource(patterns []string, typeNames ...string) ([]*tsDecl, error) {
+	pkgs, err := loadPackages(patterns...)
+	if err != nil {
+		return nil, err
+	}
+	generator := sourceGenerator{
+		options: &g.options,
+	}
+	decls, err := generator.Generate(pkgs, typeNames...)
+	g.reported = generator.list
+	g.sortDecls(decls)
+	return decls, err
+}
+
+// Writes declarations of types and globals (written in global scope after types).
+func (g *Generator) write(out io.StringWriter, decls []*tsDecl, globals []*tsDecl) error {
+	if g.module && (g.namespace != "" || g.packageNamespaces) {
+		return errors.New("namespace can't be used with module output")
+	}
+	refNames := g.resolveNames(decls)
+	updateRefs(globals, refNames, g.namespace)
+	w := typingsWriter{
+		out:     out,
+		opts:    &g.options,
+		globals: globals,
+	}
+	return w.Write(decls)
+}
+
+func (o *options) includesPackage(pkgPath string) bool {
+	if len(o.packages) == 0 {
+		return true
+	}
+	for _, p := range o.packages {
+		if strings.HasPrefix(pkgPath, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *options) includesMethods() bool {
+	return o.methods && o.profile.Methods
+}
+
+func (o *options) includesFuncs() bool {
+	return o.profile.Funcs
+}
+
+func (o *options) aliasIsObject() bool {
+	return o.profile.AliasObjects
+}
+
+// Returns type of byte slice with given type ID, base64 string (raw JSON message as is) or array of numbers.
+func (o *options) getBytesType(id string) *tsType {
+	if !o.profile.BytesAsString {
+		return o.getSliceType(tsKeywordType("number"))
+	}
+	if id == "encoding/json.RawMessage" || id == "encoding/json/jsontext.Value" {
+		return tsKeywordType("unknown")
+	}
+	return o.nullSlice(tsKeywordType("string"))
+}
+
+func (o *options) getSliceType(elem *tsType) *tsType {
+	return o.nullSlice(tsArrayType(elem))
+}
+
+func (o *options) nullSlice(t *tsType) *tsType {
+	if o.profile.NullSlices {
+		return tsNullableType(t)
+	}
+	return t
+}
+
+// Returns type of map, map with keys of enum is written as mapped type ({ [K in Status]?: V }).
+func (o *options) getMapType(key *tsType, elem *tsType, enumKey bool) *tsType {
+	t := tsRecordType(key, elem)
+	if enumKey {
+		t = tsMappedType(key, elem)
+	}
+	if o.profile.NullMaps {
+		return tsNullableType(t)
+	}
+	return t
+}
+
+// Returns type of map key with given kind, nil if key can't be represented.
+//
+// Keys of string kinds are used directly, text marshalers are strings, integers are numbers (strings if profile has StringKeys).
+func (o *options) getMapKeyType(kind reflect.Kind, isTextMarshaler bool) *tsType {
+	switch {
+	case kind == reflect.String, isTextMarshaler:
+		return tsKeywordType("string")
+	case isIntegerKind(kind) && o.profile.StringKeys:
+		return tsKeywordType("string")
+	case isIntegerKind(kind):
+		return tsKeywordType("number")
+	}
+	return nil
+}
+
+// Checks if values of enum with given kind are valid map keys (integer keys are strings in some profiles).
+func (o *options) isEnumKey(kind reflect.Kind) bool {
+	return kind == reflect.String || isIntegerKind(kind) && !o.profile.StringKeys
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// Returns type of field with omitempty, empty slice or map is omitted instead of null.
+func getOmitEmptyType(t *tsType, kind reflect.Kind) *tsType {
+	if (kind == reflect.Slice || kind == reflect.Map) && t.Kind == tsNullable {
+		return t.Args[0]
+	}
+	return t
+}
+
+// Returns type of go array, written as tuple if it is not longer than maxTupleLength.
+func (o *options) getArrayType(elem *tsType, length int) *tsType {
+	if length > o.maxTupleLength {
+		return tsArrayType(elem)
+	}
+	elems := make([]*tsType, length)
+	for i := range elems {
+		elems[i] = elem
+	}
+	return tsTupleType(elems...)
+}
+
+// Trailing error result of functions is thrown (not written as result).
+func (o *options) throwsErrors() bool {
+	return o.funcResults != FuncResultsSingle
+}
+
+// Returns type of function with given parameters and results, nil if results can't be written.
+func (o *options) getFuncType(params []*tsParam, results []*tsType, lastIsError bool) *tsType {
+	if o.funcResults == FuncResultsSingle {
+		switch len(results) {
+		case 0:
+			return tsFuncType(params, nil, false)
+		case 1:
+			return tsFuncType(params, results[0], false)
+		}
+		return nil
+	}
+
+	throws := false
+	if lastIsError {
+		results = results[:len(results)-1]
+		throws = true
+	}
+	switch len(results) {
+	case 0:
+		return tsFuncType(params, nil, throws)
+	case 1:
+		return tsFuncType(params, results[0], throws)
+	}
+	return tsFuncType(params, tsTupleType(results...), throws)
+}
@@ -0,0 +1,260 @@
+package gots_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type Author struct {
+	Contact
+	Name    string
+	Alias   StringAlias
+	Address Address
+}
+
+func (a Author) Greeting() string {
+	return "Hello " + a.Name
+}
+
+type Address struct {
+	City string
+}
+
+func Test_GeneratorOptions(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	g := gots.NewGenerator(
+		gots.WithNamespace("Models"),
+		gots.WithIndent(4),
+		gots.WithInterfaces(),
+		gots.WithExport(),
+		gots.WithHeader("Models used by views."),
+		gots.WithPackages("github.com/other", thisPackageOnly()),
+		gots.WithExcluded(Address{}),
+	)
+	g.Add(Author{})
+	g.Add(Contact{})
+	err := g.Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+// Models used by views.
+
+declare namespace Models {
+    export interface Author extends Contact {
+        Name: string
+        Alias: StringAlias
+        Address: Address
+        Greeting(): string
+    }
+
+    export interface Contact {
+        Contact: string
+        Email: string
+    }
+
+    export type StringAlias = object & { 
+        SomeAliasFalseMethod(): boolean
+        SomeAliasTrueMethod(): boolean
+    }
+
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_GeneratorRuntimeJSON(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON)).
+		Add(Author{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type Author = {
+  Name: string
+  Alias: StringAlias
+  Address: Address
+} & Contact
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+
+type StringAlias = string
+
+type Address = {
+  City: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type Library struct {
+	Books    []string
+	Tags     []string `json:",omitempty"`
+	Shelves  map[string]int
+	Labels   map[string]string `json:",omitempty"`
+	Cover    []byte
+	Borrower *Author
+}
+
+func Test_GeneratorProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		option   gots.Option
+		expected string
+	}{
+		{"goja", gots.WithRuntime(gots.RuntimeGoja), `
+type Library = {
+  Books: string[]
+  Tags?: string[]
+  Shelves: Record<string, number>
+  Labels?: Record<string, string>
+  Cover: number[]
+  Borrower: null | Author
+}
+`},
+		{"json", gots.WithRuntime(gots.RuntimeJSON), `
+type Library = {
+  Books: null | string[]
+  Tags?: string[]
+  Shelves: null | Record<string, number>
+  Labels?: Record<string, string>
+  Cover: null | string
+  Borrower: null | Author
+}
+`},
+		{"custom", gots.WithProfile(gots.Profile{NullMaps: true}), `
+type Library = {
+  Books: string[]
+  Tags?: string[]
+  Shelves: null | Record<string, number>
+  Labels?: Record<string, string>
+  Cover: number[]
+  Borrower: null | Author
+}
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBufferString("")
+
+			err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithExcluded(Author{}), tt.option).
+				Add(Library{}).
+				Generate(buf)
+			if err != nil {
+				t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+			}
+			actual := buf.String()
+			if a, e := strings.TrimSpace(actual), strings.TrimSpace(tt.expected); a != e {
+				t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+			}
+		})
+	}
+}
+
+func Test_GeneratorOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    gots.Order
+		expected []string
+	}{
+		{"reached", gots.OrderReached, []string{"Author", "Contact", "StringAlias", "Address"}},
+		{"alphabetical", gots.OrderAlphabetical, []string{"Address", "Author", "Contact", "StringAlias"}},
+		{"roots first", gots.OrderRootsFirst, []string{"Author", "Address", "Contact", "StringAlias"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBufferString("")
+
+			err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON), gots.WithOrder(tt.order)).
+				Add(Author{}).
+				Generate(buf)
+			if err != nil {
+				t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+			}
+			names := []string{}
+			for _, l := range strings.Split(buf.String(), "\n") {
+				if name, ok := strings.CutPrefix(l, "type "); ok {
+					names = append(names, strings.Fields(name)[0])
+				}
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("Order not as expected: %v", names)
+			}
+		})
+	}
+}
+
+func Test_GeneratedHeader(t *testing.T) {
+	generate := func(types ...any) string {
+		buf := bytes.NewBufferString("")
+		err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithGeneratedHeader(), gots.WithHeader("Models used by views."), gots.WithOrder(gots.OrderAlphabetical)).
+			Add(types...).
+			Generate(buf)
+		if err != nil {
+			t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+		}
+		return buf.String()
+	}
+
+	content := generate(Contact{}, Address{})
+	hash, ok := gots.GeneratedHash(content)
+	if !ok || len(hash) != 64 {
+		t.Fatalf("Expected hash in header, got:\n%s", content)
+	}
+	expected := `
+// Code generated by gots; DO NOT EDIT.
+// Hash: ` + hash + `
+// Models used by views.
+
+type Address = {
+  City: string
+}
+
+type Contact = {
+  Contact: string
+  Email: string
+}
+`
+	if a, e := strings.TrimSpace(content), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+
+	if other := generate(Address{}, Contact{}); other != content {
+		t.Errorf("Expected the same output for other order of types:\n%v", diff.LineDiff(content, other))
+	}
+	if otherHash, _ := gots.GeneratedHash(generate(Contact{})); otherHash == hash {
+		t.Errorf("Expected other hash for other types")
+	}
+	buf := bytes.NewBufferString("")
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithGeneratedHeader(), gots.WithHeader("Models used by emails."), gots.WithOrder(gots.OrderAlphabetical)).
+		Add(Contact{}, Address{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	if otherHash, _ := gots.GeneratedHash(buf.String()); otherHash == hash {
+		t.Errorf("Expected other hash for other header")
+	}
+	if _, ok := gots.GeneratedHash("type Contact = {}"); ok {
+		t.Errorf("Expected no hash in content without header")
+	}
+}
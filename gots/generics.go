@@ -0,0 +1,489 @@
+package gots
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// genericParams describes type parameters of generic type.
+type genericParams struct {
+	Names []string
+	// struct field index -> type parameter used by field (marked with tag)
+	Fields map[int]genericField
+	// struct field index -> inferred field type using type parameters
+	Patterns map[int]*typeExpr
+}
+
+type genericField struct {
+	Param int
+	// Kind of field type wrapping type parameter (pointer, slice), reflect.Invalid if field is of parameter type.
+	Wrap reflect.Kind
+}
+
+// Resolves which fields of generic struct use which type parameter.
+//
+// Fields can be marked with `waxGeneric` tag. Tag value can name the parameter (`waxGeneric:"K"`).
+// Parameter position is found by matching field type with type arguments of given instantiation.
+//
+// Other fields (embedded too) are inferred by comparing field types of all known instances of generic type
+// (`waxGeneric:"-"` marks field as not generic).
+func getGenericParams(t reflect.Type, tInfo exTypeInfo, instances []reflect.Type) genericParams {
+	args := tInfo.TypeParams
+	result := genericParams{
+		Names:    make([]string, len(args)),
+		Fields:   map[int]genericField{},
+		Patterns: map[int]*typeExpr{},
+	}
+
+	type taggedField struct {
+		index      int
+		name       string
+		wrap       reflect.Kind
+		candidates []int
+	}
+	tagged := []taggedField{}
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, isGenericParam := f.Tag.Lookup("waxGeneric")
+			if !isGenericParam || name == "-" {
+				continue
+			}
+			wrap := reflect.Invalid
+			candidates := matchTypeArgs(f.Type, args)
+			if len(candidates) == 0 {
+				wrap = f.Type.Kind()
+				candidates = matchTypeArgs(getGenericElemType(f.Type), args)
+			}
+			if len(candidates) == 0 && len(args) == 1 {
+				candidates = append(candidates, 0)
+			}
+			tagged = append(tagged, taggedField{i, name, wrap, candidates})
+		}
+	}
+
+	assign := func(f taggedField, pI int) {
+		result.Fields[f.index] = genericField{Param: pI, Wrap: f.wrap}
+		if f.name != "" && result.Names[pI] == "" {
+			result.Names[pI] = f.name
+		}
+	}
+	// unambiguous fields first, they define positions of named params
+	for _, f := range tagged {
+		if len(f.candidates) == 1 {
+			assign(f, f.candidates[0])
+		}
+	}
+	for _, f := range tagged {
+		if len(f.candidates) < 2 {
+			continue
+		}
+		pI := -1
+		for _, c := range f.candidates {
+			if f.name != "" && result.Names[c] == f.name {
+				pI = c
+				break
+			}
+			if pI == -1 && result.Names[c] == "" {
+				pI = c
+			}
+		}
+		if pI == -1 {
+			pI = f.candidates[0]
+		}
+		assign(f, pI)
+	}
+
+	if t.Kind() == reflect.Struct {
+		result.inferPatterns(t, instances)
+	}
+
+	for i := range result.Names {
+		if result.Names[i] != "" {
+			continue
+		}
+		if len(result.Names) == 1 {
+			result.Names[i] = "T"
+		} else {
+			result.Names[i] = fmt.Sprintf("T%d", i+1)
+		}
+	}
+	return result
+}
+
+func (p *genericParams) inferPatterns(t reflect.Type, instances []reflect.Type) {
+	if !slices.Contains(instances, t) {
+		instances = append([]reflect.Type{t}, instances...)
+	}
+	args := [][]string{}
+	for _, inst := range instances {
+		args = append(args, getTypeInfo(inst).TypeParams)
+	}
+	distinctive := make([]bool, len(args[0]))
+	for i := range distinctive {
+		distinctive[i] = isDistinctiveTypeArg(args, i) || isUnambiguousTypeArg(instances, i)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := p.Fields[i]; ok {
+			continue
+		}
+		if tag, ok := t.Field(i).Tag.Lookup("waxGeneric"); ok && tag == "-" {
+			continue
+		}
+		exprs := []*typeExpr{}
+		for _, inst := range instances {
+			exprs = append(exprs, parseTypeExpr(typeArgString(inst.Field(i).Type)))
+		}
+		if e := mergeTypeExprs(exprs, args, distinctive); e.hasParam() {
+			p.Patterns[i] = e
+		}
+	}
+}
+
+// Checks if type argument at given position is good enough evidence that matching field type is a type parameter.
+//
+// Type argument is distinctive if it differs between instances or is a placeholder (gots.T1...).
+// Single instance with concrete argument is not enough, field of the same type can be unrelated to parameter (use waxGeneric tag then).
+func isDistinctiveTypeArg(args [][]string, i int) bool {
+	first := args[0][i]
+	for _, a := range args[1:] {
+		if a[i] != first {
+			return true
+		}
+	}
+	return placeholderTypeArgs[first]
+}
+
+// Checks if type argument is used exactly once by fields of each instance and differs from other type arguments.
+//
+// Then field using it is typed with type parameter also when there is single instance.
+// Otherwise (eg. Label string of Box[string]) field using type parameter must be marked with waxGeneric tag.
+func isUnambiguousTypeArg(instances []reflect.Type, i int) bool {
+	for _, inst := range instances {
+		args := getTypeInfo(inst).TypeParams
+		for j, a := range args {
+			if j != i && a == args[i] {
+				return false
+			}
+		}
+		count := 0
+		for f := 0; f < inst.NumField(); f++ {
+			if tag, ok := inst.Field(f).Tag.Lookup("waxGeneric"); ok && tag == "-" {
+				continue
+			}
+			count += parseTypeExpr(typeArgString(inst.Field(f).Type)).count(args[i])
+		}
+		if count != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns names of type parameters not used by any field.
+func (p *genericParams) unusedParams() []string {
+	used := make([]bool, len(p.Names))
+	for _, f := range p.Fields {
+		used[f.Param] = true
+	}
+	for _, e := range p.Patterns {
+		e.markParams(used)
+	}
+	result := []string{}
+	for i, name := range p.Names {
+		if !used[i] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// Merges the same field type expressions of different instances, replacing type arguments with type parameters.
+func mergeTypeExprs(exprs []*typeExpr, args [][]string, distinctive []bool) *typeExpr {
+	for pI := range distinctive {
+		if !distinctive[pI] {
+			continue
+		}
+		isParam := true
+		for k, e := range exprs {
+			if e.Text != args[k][pI] {
+				isParam = false
+				break
+			}
+		}
+		if isParam {
+			return &typeExpr{Kind: typeExprParam, Text: exprs[0].Text, Param: pI}
+		}
+	}
+
+	first := exprs[0]
+	for _, e := range exprs[1:] {
+		if e.Kind != first.Kind || e.Name != first.Name || len(e.Elems) != len(first.Elems) {
+			return first
+		}
+	}
+	if len(first.Elems) == 0 {
+		return first
+	}
+	merged := *first
+	merged.Elems = []*typeExpr{}
+	for i := range first.Elems {
+		column := []*typeExpr{}
+		for _, e := range exprs {
+			column = append(column, e.Elems[i])
+		}
+		merged.Elems = append(merged.Elems, mergeTypeExprs(column, args, distinctive))
+	}
+	return &merged
+}
+
+// Placeholders for type parameters.
+//
+// Can be used to register sample of generic type, eg. Pair[gots.T1, gots.T2]{}.
+// Fields using placeholders will be typed with type parameters.
+type (
+	T1 struct{}
+	T2 struct{}
+	T3 struct{}
+	T4 struct{}
+)
+
+var placeholderTypeArgs = map[string]bool{
+	typeArgString(reflect.TypeOf(T1{})): true,
+	typeArgString(reflect.TypeOf(T2{})): true,
+	typeArgString(reflect.TypeOf(T3{})): true,
+	typeArgString(reflect.TypeOf(T4{})): true,
+}
+
+func isPlaceholderType(t reflect.Type) bool {
+	return placeholderTypeArgs[typeArgString(t)]
+}
+
+type typeExprKind int
+
+const (
+	typeExprOther typeExprKind = iota
+	typeExprParam
+	typeExprPointer
+	typeExprSlice
+	typeExprArray
+	typeExprMap
+	typeExprNamed
+)
+
+// typeExpr is parsed type string (as used in type arguments).
+type typeExpr struct {
+	Kind typeExprKind
+	Text string
+	// Package path and name for named types, length for arrays.
+	Name string
+	// Element types, key and value for maps, type arguments for generic types.
+	Elems []*typeExpr
+	// Index of type parameter.
+	Param int
+}
+
+func parseTypeExpr(s string) *typeExpr {
+	e := &typeExpr{Kind: typeExprOther, Text: s}
+	switch {
+	case strings.HasPrefix(s, "*"):
+		e.Kind = typeExprPointer
+		e.Elems = []*typeExpr{parseTypeExpr(s[1:])}
+	case strings.HasPrefix(s, "map["):
+		keyEnd := findClosingBracket(s, len("map"))
+		e.Kind = typeExprMap
+		e.Elems = []*typeExpr{parseTypeExpr(s[len("map["):keyEnd]), parseTypeExpr(s[keyEnd+1:])}
+	case strings.HasPrefix(s, "[]"):
+		e.Kind = typeExprSlice
+		e.Elems = []*typeExpr{parseTypeExpr(s[2:])}
+	case strings.HasPrefix(s, "["):
+		lenEnd := findClosingBracket(s, 0)
+		e.Kind = typeExprArray
+		e.Name = s[1:lenEnd]
+		e.Elems = []*typeExpr{parseTypeExpr(s[lenEnd+1:])}
+	default:
+		i := strings.IndexAny(s, "[ ({")
+		if i == -1 {
+			e.Kind = typeExprNamed
+			e.Name = s
+		} else if i > 0 && s[i] == '[' && strings.HasSuffix(s, "]") {
+			e.Kind = typeExprNamed
+			e.Name = s[:i]
+			for _, a := range splitTypeArgs(s[i+1 : len(s)-1]) {
+				e.Elems = append(e.Elems, parseTypeExpr(a))
+			}
+		}
+	}
+	return e
+}
+
+func (e *typeExpr) hasParam() bool {
+	if e.Kind == typeExprParam {
+		return true
+	}
+	for _, el := range e.Elems {
+		if el.hasParam() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns number of (nested) type expressions with given text.
+func (e *typeExpr) count(text string) int {
+	result := 0
+	if e.Text == text {
+		result++
+	}
+	for _, el := range e.Elems {
+		result += el.count(text)
+	}
+	return result
+}
+
+func (e *typeExpr) markParams(used []bool) {
+	if e.Kind == typeExprParam {
+		used[e.Param] = true
+	}
+	for _, el := range e.Elems {
+		el.markParams(used)
+	}
+}
+
+// Returns indexes of type arguments equal to given type.
+func matchTypeArgs(t reflect.Type, args []string) []int {
+	result := []int{}
+	argString := typeArgString(t)
+	for i, a := range args {
+		if a == argString {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// Returns type that is replaced by type parameter for field types supported by getTypeForKind.
+func getGenericElemType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		return t.Elem()
+	default:
+		return t
+	}
+}
+
+// Returns type string in the same format as reflect uses for type arguments in names of generic types.
+func typeArgString(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return t.PkgPath() + "." + t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + typeArgString(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeArgString(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeArgString(t.Elem()))
+	case reflect.Map:
+		return "map[" + typeArgString(t.Key()) + "]" + typeArgString(t.Elem())
+	default:
+		return t.String()
+	}
+}
+
+// Splits type arguments of generic type name (content of brackets) on top level commas.
+func splitTypeArgs(s string) []string {
+	result := []string{}
+	depth := 0
+	start := 0
+	for i, c := range s {
+		switch c {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, s[start:])
+}
+
+// Collects types used by generic type that can be its type arguments, by their type argument string.
+func collectTypeArgCandidates(t reflect.Type, found map[string]reflect.Type, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	found[typeArgString(t)] = t
+
+	// only generic types can pass type arguments further
+	if t.Name() != "" && !strings.Contains(t.Name(), "[") {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Chan:
+		collectTypeArgCandidates(t.Elem(), found, visited)
+	case reflect.Map:
+		collectTypeArgCandidates(t.Key(), found, visited)
+		collectTypeArgCandidates(t.Elem(), found, visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			collectTypeArgCandidates(t.Field(i).Type, found, visited)
+		}
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			collectTypeArgCandidates(t.In(i), found, visited)
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			collectTypeArgCandidates(t.Out(i), found, visited)
+		}
+	}
+}
+
+// Returns index of bracket closing the one at given position.
+func findClosingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s) - 1
+}
+
+var basicKindsByName = map[string]reflect.Kind{
+	"bool":       reflect.Bool,
+	"string":     reflect.String,
+	"int":        reflect.Int,
+	"int8":       reflect.Int8,
+	"int16":      reflect.Int16,
+	"int32":      reflect.Int32,
+	"int64":      reflect.Int64,
+	"uint":       reflect.Uint,
+	"uint8":      reflect.Uint8,
+	"uint16":     reflect.Uint16,
+	"uint32":     reflect.Uint32,
+	"uint64":     reflect.Uint64,
+	"uintptr":    reflect.Uintptr,
+	"float32":    reflect.Float32,
+	"float64":    reflect.Float64,
+	"complex64":  reflect.Complex64,
+	"complex128": reflect.Complex128,
+}
@@ -0,0 +1,70 @@
+package gots
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Generates typescript typings of globals exposed to goja runtime (helper functions and objects) and types they reference.
+//
+// Functions are written as declare function formatMoney(p1: number): string, other values as declare const flags: Flags.
+//
+// Use Generator for more options.
+func GenerateGlobalDefinitions(out io.StringWriter, globals map[string]any) error {
+	return NewGenerator().GenerateGlobals(out, globals)
+}
+
+// Generates typings of registered types and given globals (by name in runtime) with types they reference, globals are written after types.
+//
+// Globals are declared in global scope also with namespace or module output (declare global { ... }).
+func (g *Generator) GenerateGlobals(out io.StringWriter, globals map[string]any) error {
+	generator := definitionGenerator{
+		options: &g.options,
+		globals: globals,
+	}
+	decls, err := generator.Generate(g.types...)
+	g.reported = generator.list
+	if err != nil {
+		return err
+	}
+	g.sortDecls(decls)
+	return g.write(out, decls, generator.globalDecls)
+}
+
+// Writes declarations of globals, returns types used by them.
+func (g *definitionGenerator) writeGlobals() ([]reflect.Type, error) {
+	usedTypes := []reflect.Type{}
+	for _, name := range slices.Sorted(maps.Keys(g.globals)) {
+		if !isValidIdentifier(name) {
+			return nil, fmt.Errorf("global %s is not valid identifier", name)
+		}
+		v := g.globals[name]
+		if v == nil {
+			return nil, fmt.Errorf("global %s is nil", name)
+		}
+		g.path = name
+		decl := &tsDecl{
+			ID:           "global:" + name,
+			Name:         name,
+			ExplicitName: true,
+			Root:         true,
+		}
+		t := getUnderlyingType(reflect.TypeOf(v))
+		if t.Kind() == reflect.Func {
+			fn, used := g.getFuncSignature(t, 0)
+			decl.Type = fn
+			for _, u := range used {
+				usedTypes = append(usedTypes, getUsedTypes(u)...)
+			}
+		}
+		if decl.Type == nil {
+			decl.Type = g.getTypingName(t)
+			usedTypes = append(usedTypes, getUsedTypes(t)...)
+		}
+		g.globalDecls = append(g.globalDecls, decl)
+	}
+	return usedTypes, nil
+}
@@ -0,0 +1,152 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type GlobalFlags struct {
+	Beta bool
+}
+
+type GlobalUser struct {
+	Name string
+}
+
+func formatMoney(v float64) string {
+	return ""
+}
+
+func userURL(u GlobalUser, page ...int) (string, error) {
+	return "", nil
+}
+
+func Test_GlobalDefinitions(t *testing.T) {
+	globals := map[string]any{
+		"formatMoney": formatMoney,
+		"userURL":     userURL,
+		"flags":       &GlobalFlags{},
+		"version":     "1.0",
+	}
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateGlobalDefinitions(buf, globals)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type GlobalFlags = {
+  Beta: boolean
+}
+
+type GlobalUser = {
+  Name: string
+}
+
+declare const flags: GlobalFlags
+
+declare function formatMoney(p1: number): string
+
+/** @throws error returned by go function */
+declare function userURL(p1: GlobalUser, ...p2: number[]): string
+
+declare const version: string
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithModule()).
+		GenerateGlobals(buf, globals)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+export type GlobalFlags = {
+  Beta: boolean
+}
+
+export type GlobalUser = {
+  Name: string
+}
+
+declare global {
+  const flags: GlobalFlags
+
+  function formatMoney(p1: number): string
+
+  /** @throws error returned by go function */
+  function userURL(p1: GlobalUser, ...p2: number[]): string
+
+  const version: string
+
+}
+`
+	actual = buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	buf = bytes.NewBufferString("")
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithNamespace("Models")).
+		GenerateGlobals(buf, map[string]any{"flags": GlobalFlags{}})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected = `
+declare namespace Models {
+  type GlobalFlags = {
+    Beta: boolean
+  }
+
+}
+
+declare const flags: Models.GlobalFlags
+`
+	actual = buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	err = gots.GenerateGlobalDefinitions(bytes.NewBufferString(""), map[string]any{"format-money": formatMoney})
+	if err == nil || err.Error() != "global format-money is not valid identifier" {
+		t.Errorf("Expected error for invalid name, got %v", err)
+	}
+}
+
+func Test_GlobalsOfUnnamedType(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithStrict(), gots.WithRuntime(gots.RuntimeJSON)).
+		GenerateGlobals(buf, map[string]any{
+			"flags": map[string]GlobalFlags{},
+			"users": []*GlobalUser{},
+		})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type GlobalFlags = {
+  Beta: boolean
+}
+
+type GlobalUser = {
+  Name: string
+}
+
+declare const flags: null | Record<string, GlobalFlags>
+
+declare const users: null | (null | GlobalUser)[]
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
@@ -2,6 +2,13 @@ module github.com/michal-laskowski/wax-libs/gots
 
 go 1.23.2
 
-require github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883
+require (
+	github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883
+	golang.org/x/tools v0.36.0
+)
 
-require github.com/sergi/go-diff v1.3.1 // indirect
+require (
+	github.com/sergi/go-diff v1.3.1 // indirect
+	golang.org/x/mod v0.27.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+)
@@ -0,0 +1,85 @@
+// Package gotstest checks in tests that committed typings are up to date with go types.
+//
+//	func Test_Typings(t *testing.T) {
+//		gotstest.AssertTypingsUpToDate(t, "../web/types/models.d.ts", []gots.Option{gots.WithPackages("github.com/some/app")}, models.User{})
+//	}
+//
+// Run tests of the package with -gotstest.update flag to rewrite typings (eg. go test ./models -gotstest.update).
+// Plain -update flag is not registered by default (test package can define its own, eg. for golden files), if test package defines it,
+// it rewrites typings too. Otherwise call RegisterUpdateFlag to get it:
+//
+//	func init() { gotstest.RegisterUpdateFlag() }
+package gotstest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+// -update is not registered, test package can define it (eg. for golden files).
+var update = flag.Bool("gotstest.update", false, "rewrite typings checked by gotstest instead of comparing them")
+
+// Registers -update flag rewriting typings, if test package doesn't define it already.
+//
+// Call it from init function of test package, so flag is registered before tests parse flags.
+func RegisterUpdateFlag() {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "rewrite typings checked by gotstest instead of comparing them")
+	}
+}
+
+// Returns true if typings should be rewritten, -update flag of test package is looked up when test runs.
+func shouldUpdate() bool {
+	if *update {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		v, _ := strconv.ParseBool(f.Value.String())
+		return v
+	}
+	return false
+}
+
+// Generates typings of given types with given options and compares them with file at path, reports readable diff if they differ.
+//
+// With -gotstest.update (or -update, see RegisterUpdateFlag) flag the file is rewritten instead.
+func AssertTypingsUpToDate(t testing.TB, path string, opts []gots.Option, types ...any) {
+	t.Helper()
+
+	sb := &strings.Builder{}
+	if err := gots.NewGenerator(opts...).Add(types...).Generate(sb); err != nil {
+		t.Fatalf("gotstest: generating typings for %s: %v", path, err)
+		return
+	}
+	actual := sb.String()
+
+	if shouldUpdate() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("gotstest: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Fatalf("gotstest: %v", err)
+			return
+		}
+		t.Logf("gotstest: updated %s", path)
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("gotstest: %v (run tests with -gotstest.update to create it)", err)
+		return
+	}
+	if string(expected) != actual {
+		t.Errorf("gotstest: %s is not up to date (run tests with -gotstest.update to rewrite it):\n%s", path, diff.LineDiff(string(expected), actual))
+	}
+}
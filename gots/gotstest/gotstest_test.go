@@ -0,0 +1,126 @@
+package gotstest_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+	"github.com/michal-laskowski/wax-libs/gots/gotstest"
+)
+
+// test package defines its own -update flag, gotstest reuses it
+var update = flag.Bool("update", false, "rewrite golden files")
+
+type Contact struct {
+	Name  string
+	Email string
+}
+
+type OtherContact struct {
+	Name string
+}
+
+// recorder records failures instead of failing test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func (r *recorder) Logf(format string, args ...any) {}
+
+func Test_AssertTypingsUpToDate(t *testing.T) {
+	gotstest.AssertTypingsUpToDate(t, "testdata/contact.d.ts", nil, Contact{})
+}
+
+func Test_AssertTypingsUpToDateMismatch(t *testing.T) {
+	r := &recorder{TB: t}
+	gotstest.AssertTypingsUpToDate(r, "testdata/contact.d.ts", []gots.Option{gots.WithRename(OtherContact{}, "Contact")}, OtherContact{})
+	if len(r.failures) != 1 {
+		t.Fatalf("Expected one failure, got %v", r.failures)
+	}
+	for _, expected := range []string{"testdata/contact.d.ts is not up to date", "-gotstest.update", "Email: string"} {
+		if !strings.Contains(r.failures[0], expected) {
+			t.Errorf("Expected %q in failure:\n%s", expected, r.failures[0])
+		}
+	}
+
+	r = &recorder{TB: t}
+	gotstest.AssertTypingsUpToDate(r, "testdata/missing.d.ts", nil, Contact{})
+	if len(r.failures) != 1 || !strings.Contains(r.failures[0], "run tests with -gotstest.update to create it") {
+		t.Errorf("Expected failure for missing file, got %v", r.failures)
+	}
+}
+
+func Test_AssertTypingsUpToDateUpdate(t *testing.T) {
+	for _, name := range []string{"gotstest.update", "update"} {
+		t.Run(name, func(t *testing.T) {
+			if err := flag.Set(name, "true"); err != nil {
+				t.Fatal(err)
+			}
+			defer flag.Set(name, "false")
+			assertUpdated(t)
+		})
+	}
+	if *update {
+		t.Errorf("Expected -update flag of test package to be reset")
+	}
+}
+
+func Test_RegisterUpdateFlag(t *testing.T) {
+	// -update of test package is kept
+	gotstest.RegisterUpdateFlag()
+	if f := flag.Lookup("update"); f == nil || f.Usage != "rewrite golden files" {
+		t.Errorf("Expected -update flag of test package, got %v", f)
+	}
+
+	// test package without own flag gets -update
+	path := filepath.Join(t.TempDir(), "contact.d.ts")
+	cmd := exec.Command("go", "test", "-count=1", "./testdata/register", "-update")
+	cmd.Env = append(os.Environ(), "GOTSTEST_PATH="+path)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v\n%s", err, out)
+	}
+	actual, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/contact.d.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != string(expected) {
+		t.Errorf("Updated file not as expected:\n%s", actual)
+	}
+}
+
+func assertUpdated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "types", "contact.d.ts")
+	gotstest.AssertTypingsUpToDate(t, path, nil, Contact{})
+
+	actual, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/contact.d.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != string(expected) {
+		t.Errorf("Updated file not as expected:\n%s", actual)
+	}
+}
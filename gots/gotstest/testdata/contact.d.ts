@@ -0,0 +1,5 @@
+type Contact = {
+  Name: string
+  Email: string
+}
+
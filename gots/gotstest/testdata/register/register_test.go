@@ -0,0 +1,20 @@
+// Package register is test package without own -update flag, used by tests of RegisterUpdateFlag.
+package register_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/michal-laskowski/wax-libs/gots/gotstest"
+)
+
+func init() { gotstest.RegisterUpdateFlag() }
+
+type Contact struct {
+	Name  string
+	Email string
+}
+
+func Test_Typings(t *testing.T) {
+	gotstest.AssertTypingsUpToDate(t, os.Getenv("GOTSTEST_PATH"), nil, Contact{})
+}
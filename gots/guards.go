@@ -0,0 +1,310 @@
+package gots
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Generates typescript module (.ts) with runtime type guards for registered types.
+//
+// For each declared type writes assertion function with path aware errors and type guard using it:
+//
+//	export function assertContact(v: unknown, path: string = "Contact"): asserts v is Contact
+//	export function isContact(v: unknown): v is Contact
+//
+// Guards of generic types take checks of type arguments (eg. assertPage<T>(v, path, checkT)).
+// If typesImport is given, types are imported from it (eg. "./models"), otherwise typings are expected to be global.
+func (g *Generator) GenerateGuards(out io.StringWriter, typesImport string) error {
+	decls, err := g.generateDecls()
+	if err != nil {
+		return err
+	}
+	return g.writeGuards(out, decls, typesImport)
+}
+
+// Same as GenerateGuards but for types loaded from source of given packages (see GenerateFromSource).
+func (g *Generator) GenerateGuardsFromSource(out io.StringWriter, typesImport string, patterns []string, typeNames ...string) error {
+	decls, err := g.generateDeclsFromSource(patterns, typeNames...)
+	if err != nil {
+		return err
+	}
+	return g.writeGuards(out, decls, typesImport)
+}
+
+const guardsPrelude = `export type Check = (v: unknown, path: string) => void
+
+function fail(path: string, expected: string, v: unknown): never {
+  throw new TypeError(` + "`${path}: expected ${expected}, got ${v === null ? \"null\" : Array.isArray(v) ? \"array\" : typeof v}`" + `)
+}
+
+function checkType(v: unknown, type: string, path: string): void {
+  if (typeof v !== type) fail(path, type, v)
+}
+
+function checkPresent(v: unknown, path: string): void {
+  if (v === null || v === undefined) fail(path, "value", v)
+}
+
+function checkObject(v: unknown, path: string): asserts v is Record<string, unknown> {
+  if (typeof v !== "object" || v === null || Array.isArray(v)) fail(path, "object", v)
+}
+
+function checkArray(v: unknown, path: string, item: Check): void {
+  if (!Array.isArray(v)) fail(path, "array", v)
+  v.forEach((e, i) => item(e, ` + "`${path}[${i}]`" + `))
+}
+
+function checkTuple(v: unknown, path: string, items: Check[]): void {
+  if (!Array.isArray(v) || v.length !== items.length) fail(path, ` + "`array of length ${items.length}`" + `, v)
+  for (let i = 0; i < items.length; i++) items[i](v[i], ` + "`${path}[${i}]`" + `)
+}
+
+function checkRecord(v: unknown, path: string, item: Check): void {
+  checkObject(v, path)
+  for (const [k, e] of Object.entries(v)) item(e, ` + "`${path}.${k}`" + `)
+}
+
+function checkNullable(v: unknown, path: string, item: Check): void {
+  if (v !== null) item(v, path)
+}
+
+function checkOptional(v: unknown, path: string, item: Check): void {
+  if (v !== undefined) item(v, path)
+}
+
+function checkValues(v: unknown, path: string, values: unknown[]): void {
+  if (!values.includes(v)) fail(path, values.map((e) => JSON.stringify(e)).join(" | "), v)
+}
+
+function checkUnion(v: unknown, path: string, discriminator: string, variants: Map<unknown, Check>): void {
+  checkObject(v, path)
+  const variant = variants.get(v[discriminator])
+  if (!variant) fail(` + "`${path}.${discriminator}`" + `, [...variants.keys()].map((e) => JSON.stringify(e)).join(" | "), v[discriminator])
+  variant(v, path)
+}
+`
+
+// guardsWriter writes declarations as runtime type guards.
+type guardsWriter struct {
+	typingsWriter
+	// names of guarded types (as visible in guards module) by declaration ID
+	typeNames map[string]string
+	// names of guard functions (without assert/is prefix) by declaration ID
+	funcNames map[string]string
+}
+
+func (g *Generator) writeGuards(out io.StringWriter, decls []*tsDecl, typesImport string) error {
+	g.resolveNames(decls)
+	pkgNames := getModuleNames(getDeclPackages(decls))
+
+	w := guardsWriter{
+		typingsWriter: typingsWriter{out: out, opts: &g.options},
+		typeNames:     map[string]string{},
+		funcNames:     map[string]string{},
+	}
+	imported := []string{}
+	for _, d := range decls {
+		typeName, funcName := d.Name, d.Name
+		if g.packageNamespaces {
+			typeName = getNamespaceName(pkgNames[d.PkgPath]) + "." + d.Name
+			funcName = defaultNamePrefix(d.PkgPath, pkgNames[d.PkgPath]) + d.Name
+		}
+		if typesImport == "" && g.namespace != "" {
+			typeName = g.namespace + "." + typeName
+		}
+		w.typeNames[d.ID] = typeName
+		w.funcNames[d.ID] = funcName
+		imported = append(imported, strings.Split(typeName, ".")[0])
+	}
+
+	w.writeHeader(decls)
+	if typesImport != "" && len(imported) > 0 {
+		names := slices.Compact(slices.Sorted(slices.Values(imported)))
+		w.outLine(fmt.Sprintf("import type { %s } from %s", strings.Join(names, ", "), strconv.Quote(typesImport)))
+		w.outEndLine()
+	}
+	w.outNext(guardsPrelude)
+	for _, d := range decls {
+		w.outEndLine()
+		w.writeGuard(d)
+	}
+	return nil
+}
+
+func (w *guardsWriter) writeGuard(d *tsDecl) {
+	typeName, funcName := w.typeNames[d.ID], w.funcNames[d.ID]
+	typeParams, checkParams, checkArgs := "", "", ""
+	if len(d.TypeParams) > 0 {
+		typeParams = "<" + strings.Join(d.TypeParams, ", ") + ">"
+		typeName += typeParams
+		for _, p := range d.TypeParams {
+			checkParams += fmt.Sprintf(", check%s: Check", p)
+			checkArgs += ", check" + p
+		}
+	}
+
+	pathParam := fmt.Sprintf("path: string = %s", strconv.Quote(d.Name))
+	if len(d.TypeParams) > 0 {
+		// parameters with default value must be last
+		pathParam = "path: string"
+	}
+	w.outLine(fmt.Sprintf("export function assert%s%s(v: unknown, %s%s): asserts v is %s {", funcName, typeParams, pathParam, checkParams, typeName))
+	w.doIndent()
+	for _, l := range w.declChecks(d) {
+		w.outLine(l)
+	}
+	w.doDeIndent()
+	w.outLine("}")
+	w.outEndLine()
+
+	isPath := ""
+	if len(d.TypeParams) > 0 {
+		isPath = ", " + strconv.Quote(d.Name)
+	}
+	w.outLine(fmt.Sprintf("export function is%s%s(v: unknown%s): v is %s {", funcName, typeParams, checkParams, typeName))
+	w.doIndent()
+	w.outLine("try {")
+	w.doIndent()
+	w.outLine(fmt.Sprintf("assert%s(v%s%s)", funcName, isPath, checkArgs))
+	w.outLine("return true")
+	w.doDeIndent()
+	w.outLine("} catch {")
+	w.doIndent()
+	w.outLine("return false")
+	w.doDeIndent()
+	w.outLine("}")
+	w.doDeIndent()
+	w.outLine("}")
+}
+
+// Returns statements checking value v of declared type.
+func (w *guardsWriter) declChecks(d *tsDecl) []string {
+	if len(d.Values) > 0 {
+		literals := []string{}
+		for _, v := range d.Values {
+			literals = append(literals, v.Value)
+		}
+		return []string{fmt.Sprintf("checkValues(v, path, [%s])", strings.Join(literals, ", "))}
+	}
+	if d.IsAliasObject {
+		return []string{"checkPresent(v, path)"}
+	}
+	if d.Discriminator != "" {
+		variants := []string{}
+		for _, v := range d.Variants {
+			variants = append(variants, fmt.Sprintf("[%s, %s]", v.Value, w.checkOrNothing(v.Type)))
+		}
+		return []string{fmt.Sprintf("checkUnion(v, path, %s, new Map<unknown, Check>([%s]))", strconv.Quote(d.Discriminator), strings.Join(variants, ", "))}
+	}
+	if d.Type != nil && len(d.Members) == 0 {
+		if c := w.checkCall(d.Type, "v", "path"); c != "" {
+			return []string{c}
+		}
+		return nil
+	}
+	return w.objectChecks(d.Members, d.Extends)
+}
+
+func (w *guardsWriter) objectChecks(members []*tsMember, extends []*tsType) []string {
+	result := []string{"checkObject(v, path)"}
+	for _, e := range extends {
+		if c := w.checkCall(e, "v", "path"); c != "" {
+			result = append(result, c)
+		}
+	}
+	for _, m := range members {
+		if m.Comment != "" {
+			continue
+		}
+		value := fmt.Sprintf("v[%s]", strconv.Quote(m.Name))
+		path := fmt.Sprintf("path + %s", strconv.Quote("."+m.Name))
+		switch {
+		case m.IsMethod:
+			result = append(result, fmt.Sprintf(`checkType(%s, "function", %s)`, value, path))
+		case m.Optional:
+			if c := w.check(m.Type); c != "" {
+				result = append(result, fmt.Sprintf("checkOptional(%s, %s, %s)", value, path, c))
+			}
+		default:
+			if c := w.checkCall(m.Type, value, path); c != "" {
+				result = append(result, c)
+			}
+		}
+	}
+	return result
+}
+
+// Returns statement checking value at path, empty if value of type is not checked.
+func (w *guardsWriter) checkCall(t *tsType, value string, path string) string {
+	switch t.Kind {
+	case tsKeyword:
+		switch t.Name {
+		case "string", "number", "boolean":
+			return fmt.Sprintf("checkType(%s, %s, %s)", value, strconv.Quote(t.Name), path)
+		case "object":
+			return fmt.Sprintf("checkPresent(%s, %s)", value, path)
+		}
+	case tsTypeParam:
+		return fmt.Sprintf("check%s(%s, %s)", t.Name, value, path)
+	case tsNullable:
+		if c := w.check(t.Args[0]); c != "" {
+			return fmt.Sprintf("checkNullable(%s, %s, %s)", value, path, c)
+		}
+	case tsArray:
+		return fmt.Sprintf("checkArray(%s, %s, %s)", value, path, w.checkOrNothing(t.Args[0]))
+	case tsTuple:
+		items := []string{}
+		for _, e := range t.Args {
+			items = append(items, w.checkOrNothing(e))
+		}
+		return fmt.Sprintf("checkTuple(%s, %s, [%s])", value, path, strings.Join(items, ", "))
+	case tsRecord, tsMapped:
+		return fmt.Sprintf("checkRecord(%s, %s, %s)", value, path, w.checkOrNothing(t.Args[1]))
+	case tsFunc:
+		return fmt.Sprintf(`checkType(%s, "function", %s)`, value, path)
+	case tsLiteral:
+		return fmt.Sprintf("checkValues(%s, %s, [%s])", value, path, t.Name)
+	case tsObject:
+		return fmt.Sprintf("(%s)(%s, %s)", w.check(t), value, path)
+	case tsRef:
+		funcName, ok := w.funcNames[t.Ref]
+		if !ok {
+			// not generated (excluded) type
+			return ""
+		}
+		args := []string{value, path}
+		for _, a := range t.Args {
+			args = append(args, w.checkOrNothing(a))
+		}
+		return fmt.Sprintf("assert%s(%s)", funcName, strings.Join(args, ", "))
+	}
+	return ""
+}
+
+// Returns check (function of value and path) for type, empty if value of type is not checked.
+func (w *guardsWriter) check(t *tsType) string {
+	switch t.Kind {
+	case tsTypeParam:
+		return "check" + t.Name
+	case tsObject:
+		return fmt.Sprintf("(v, path) => { %s }", strings.Join(w.objectChecks(t.Members, t.Extends), "; "))
+	case tsRef:
+		if funcName, ok := w.funcNames[t.Ref]; ok && len(t.Args) == 0 {
+			return "assert" + funcName
+		}
+	}
+	if c := w.checkCall(t, "v", "p"); c != "" {
+		return "(v, p) => " + c
+	}
+	return ""
+}
+
+func (w *guardsWriter) checkOrNothing(t *tsType) string {
+	if c := w.check(t); c != "" {
+		return c
+	}
+	return "() => {}"
+}
@@ -0,0 +1,104 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type GuardOrder struct {
+	Contact
+	ID     int            `json:"id"`
+	Note   *string        `json:"note,omitempty"`
+	Tags   []*string      `json:"tags"`
+	Lines  map[string]int `json:"lines"`
+	Status AccountStatus  `json:"status"`
+	Page   Page[Contact]  `json:"page"`
+}
+
+func Test_Guards(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON)).
+		Add(GuardOrder{}, gots.Enum(AccountActive, AccountDisabled)).
+		GenerateGuards(buf, "./models")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	actual := buf.String()
+	_, actual, _ = strings.Cut(actual, "export function assertGuardOrder")
+	expected := `
+(v: unknown, path: string = "GuardOrder"): asserts v is GuardOrder {
+  checkObject(v, path)
+  assertContact(v, path)
+  checkType(v["id"], "number", path + ".id")
+  checkOptional(v["note"], path + ".note", (v, p) => checkNullable(v, p, (v, p) => checkType(v, "string", p)))
+  checkNullable(v["tags"], path + ".tags", (v, p) => checkArray(v, p, (v, p) => checkNullable(v, p, (v, p) => checkType(v, "string", p))))
+  checkNullable(v["lines"], path + ".lines", (v, p) => checkRecord(v, p, (v, p) => checkType(v, "number", p)))
+  assertAccountStatus(v["status"], path + ".status")
+  assertPage(v["page"], path + ".page", assertContact)
+}
+
+export function isGuardOrder(v: unknown): v is GuardOrder {
+  try {
+    assertGuardOrder(v)
+    return true
+  } catch {
+    return false
+  }
+}
+
+export function assertAccountStatus(v: unknown, path: string = "AccountStatus"): asserts v is AccountStatus {
+  checkValues(v, path, ["active", "disabled"])
+}
+
+export function isAccountStatus(v: unknown): v is AccountStatus {
+  try {
+    assertAccountStatus(v)
+    return true
+  } catch {
+    return false
+  }
+}
+
+export function assertContact(v: unknown, path: string = "Contact"): asserts v is Contact {
+  checkObject(v, path)
+  checkType(v["Contact"], "string", path + ".Contact")
+  checkType(v["Email"], "string", path + ".Email")
+}
+
+export function isContact(v: unknown): v is Contact {
+  try {
+    assertContact(v)
+    return true
+  } catch {
+    return false
+  }
+}
+
+export function assertPage<T>(v: unknown, path: string, checkT: Check): asserts v is Page<T> {
+  checkObject(v, path)
+  checkNullable(v["Items"], path + ".Items", (v, p) => checkArray(v, p, checkT))
+  checkType(v["Total"], "number", path + ".Total")
+}
+
+export function isPage<T>(v: unknown, checkT: Check): v is Page<T> {
+  try {
+    assertPage(v, "Page", checkT)
+    return true
+  } catch {
+    return false
+  }
+}
+`
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+	if !strings.HasPrefix(buf.String(), `import type { AccountStatus, Contact, GuardOrder, Page } from "./models"`) {
+		t.Errorf("Types not imported:\n%v", buf.String())
+	}
+}
@@ -0,0 +1,106 @@
+package gots
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// jsonFieldInfo describes how encoding/json treats a struct field.
+type jsonFieldInfo struct {
+	// Name from tag, empty if tag does not rename field.
+	Name      string
+	FieldName string
+	Skip      bool
+	Optional  bool
+	AsString  bool
+	// Fields of embedded struct are promoted also when it is visible under Name (goja with field name mapper).
+	Promoted bool
+}
+
+func getJSONFieldInfo(f reflect.StructField) jsonFieldInfo {
+	isScalar := false
+	// encoding/json applies ",string" only to scalar fields
+	switch getUnderlyingType(f.Type).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		isScalar = true
+	}
+	return parseJSONTag(f.Name, f.Tag, isScalar)
+}
+
+func parseJSONTag(fieldName string, fieldTag reflect.StructTag, isScalar bool) jsonFieldInfo {
+	info := jsonFieldInfo{FieldName: fieldName}
+
+	tag, ok := fieldTag.Lookup("json")
+	if !ok {
+		return info
+	}
+	if tag == "-" {
+		info.Skip = true
+		return info
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if isValidJSONTagName(name) {
+		info.Name = name
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		switch opt {
+		case "omitempty", "omitzero":
+			info.Optional = true
+		case "string":
+			info.AsString = isScalar
+		}
+	}
+	return info
+}
+
+// Returns property name used by encoding/json.
+func (i jsonFieldInfo) name() string {
+	if i.Name != "" {
+		return i.Name
+	}
+	return i.FieldName
+}
+
+func quotePropertyName(name string) string {
+	if isValidIdentifier(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func isValidIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_' || c == '$' || unicode.IsLetter(c):
+		case i > 0 && unicode.IsDigit(c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Same rules as in encoding/json.
+func isValidJSONTagName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
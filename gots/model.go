@@ -0,0 +1,199 @@
+package gots
+
+// tsDecl is typescript type declaration built from go type.
+type tsDecl struct {
+	// Package path and name of go type (without type arguments).
+	ID         string
+	PkgPath    string
+	Name       string
+	Doc        string
+	TypeParams []string
+	// Name given explicitly (rename option or directive), not changed on collision.
+	ExplicitName bool
+	// Type was registered (not reached from other type).
+	Root bool
+	// Registered instances of generic type (references with type arguments).
+	Instances []*tsType
+	// Alias to base type is an object in goja.
+	IsAliasObject bool
+	// Aliased type, declaration is written as type X = T (if there are no members).
+	Type    *tsType
+	Members []*tsMember
+	Extends []*tsType
+
+	// Known values of type (constants), written as union of literals.
+	Values []*tsValue
+	// Write also const object with named values.
+	ValuesObject bool
+
+	// Name of discriminator property of discriminated union (Type is union of variants).
+	Discriminator string
+	Variants      []*tsVariant
+}
+
+// tsVariant is implementation of interface written as discriminated union.
+type tsVariant struct {
+	// Typescript literal of discriminator.
+	Value string
+	Type  *tsType
+}
+
+type tsValue struct {
+	Name string
+	Doc  string
+	// Typescript literal.
+	Value string
+}
+
+type tsMember struct {
+	Name     string
+	Doc      string
+	Optional bool
+	Type     *tsType
+
+	IsMethod bool
+	Params   []*tsParam
+	// Nil if method returns nothing.
+	Result *tsType
+	// Method throws error returned by go method.
+	Throws bool
+
+	// Member not supported, only comment is written.
+	Comment string
+}
+
+type tsParam struct {
+	Name     string
+	Type     *tsType
+	Variadic bool
+}
+
+type tsTypeKind int
+
+const (
+	// Predefined type (string, number, any, ...).
+	tsKeyword tsTypeKind = iota
+	// Reference to declared type.
+	tsRef
+	// Type parameter of generic type.
+	tsTypeParam
+	tsNullable
+	tsArray
+	tsRecord
+	// Inline object type.
+	tsObject
+	// Function type.
+	tsFunc
+	// Tuple of element types.
+	tsTuple
+	// Object with optional property for each value of key type ({ [K in Status]?: V }).
+	tsMapped
+	// Union of types (A | B).
+	tsUnion
+	// Literal type, Name is typescript literal ("text").
+	tsLiteral
+)
+
+// tsType is typescript type expression.
+type tsType struct {
+	Kind tsTypeKind
+	// Keyword, name of type parameter or referenced type.
+	Name string
+	// ID of referenced declaration.
+	Ref string
+	// Type arguments, element type, key and value of record or mapped type.
+	Args    []*tsType
+	Members []*tsMember
+	Extends []*tsType
+	// Parameters and result of function type (nil if function returns nothing).
+	Params []*tsParam
+	Result *tsType
+	// Function throws error returned by go function.
+	Throws bool
+}
+
+func tsKeywordType(name string) *tsType {
+	return &tsType{Kind: tsKeyword, Name: name}
+}
+
+func tsRefType(id string, name string, args ...*tsType) *tsType {
+	return &tsType{Kind: tsRef, Ref: id, Name: name, Args: args}
+}
+
+func tsTypeParamType(name string) *tsType {
+	return &tsType{Kind: tsTypeParam, Name: name}
+}
+
+func tsNullableType(t *tsType) *tsType {
+	return &tsType{Kind: tsNullable, Args: []*tsType{t}}
+}
+
+func tsArrayType(elem *tsType) *tsType {
+	return &tsType{Kind: tsArray, Args: []*tsType{elem}}
+}
+
+func tsRecordType(key *tsType, elem *tsType) *tsType {
+	return &tsType{Kind: tsRecord, Args: []*tsType{key, elem}}
+}
+
+func tsMappedType(key *tsType, elem *tsType) *tsType {
+	return &tsType{Kind: tsMapped, Args: []*tsType{key, elem}}
+}
+
+func tsTupleType(elems ...*tsType) *tsType {
+	return &tsType{Kind: tsTuple, Args: elems}
+}
+
+func tsUnionType(elems ...*tsType) *tsType {
+	return &tsType{Kind: tsUnion, Args: elems}
+}
+
+func tsLiteralType(literal string) *tsType {
+	return &tsType{Kind: tsLiteral, Name: literal}
+}
+
+func tsFuncType(params []*tsParam, result *tsType, throws bool) *tsType {
+	return &tsType{Kind: tsFunc, Params: params, Result: result, Throws: throws}
+}
+
+// Calls fn for all type expressions used by declaration (including nested ones).
+func (d *tsDecl) walkTypes(fn func(t *tsType)) {
+	if d.Type != nil {
+		d.Type.walk(fn)
+	}
+	for _, e := range d.Extends {
+		e.walk(fn)
+	}
+	walkMembers(d.Members, fn)
+}
+
+func (t *tsType) walk(fn func(t *tsType)) {
+	fn(t)
+	for _, a := range t.Args {
+		a.walk(fn)
+	}
+	for _, p := range t.Params {
+		p.Type.walk(fn)
+	}
+	if t.Result != nil {
+		t.Result.walk(fn)
+	}
+	for _, e := range t.Extends {
+		e.walk(fn)
+	}
+	walkMembers(t.Members, fn)
+}
+
+func walkMembers(members []*tsMember, fn func(t *tsType)) {
+	for _, m := range members {
+		if m.Type != nil {
+			m.Type.walk(fn)
+		}
+		for _, p := range m.Params {
+			p.Type.walk(fn)
+		}
+		if m.Result != nil {
+			m.Result.walk(fn)
+		}
+	}
+}
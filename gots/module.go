@@ -0,0 +1,95 @@
+package gots
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Generates one ES module (.d.ts) per go package of registered types, modules import types from each other (import type { X } from "./other").
+//
+// Returns content of modules by file name. File is named by last element of package path (more elements are used if names collide).
+func (g *Generator) GenerateModules() (map[string]string, error) {
+	decls, err := g.generateDecls()
+	if err != nil {
+		return nil, err
+	}
+	return g.writeModules(decls)
+}
+
+// Same as GenerateModules but for types loaded from source of given packages (see GenerateFromSource).
+func (g *Generator) GenerateModulesFromSource(patterns []string, typeNames ...string) (map[string]string, error) {
+	decls, err := g.generateDeclsFromSource(patterns, typeNames...)
+	if err != nil {
+		return nil, err
+	}
+	return g.writeModules(decls)
+}
+
+func (g *Generator) writeModules(decls []*tsDecl) (map[string]string, error) {
+	if g.namespace != "" || g.packageNamespaces {
+		return nil, errors.New("namespace can't be used with module output")
+	}
+	opts := g.options
+	opts.module = true
+	opts.resolveNames(decls)
+
+	pkgs := getDeclPackages(decls)
+	declsByPkg := map[string][]*tsDecl{}
+	pkgByID := map[string]string{}
+	for _, d := range decls {
+		declsByPkg[d.PkgPath] = append(declsByPkg[d.PkgPath], d)
+		pkgByID[d.ID] = d.PkgPath
+	}
+	moduleNames := getModuleNames(pkgs)
+
+	result := map[string]string{}
+	for _, pkg := range pkgs {
+		imports := map[string][]string{}
+		for _, d := range declsByPkg[pkg] {
+			d.walkTypes(func(t *tsType) {
+				refPkg, ok := pkgByID[t.Ref]
+				if t.Kind != tsRef || !ok || refPkg == pkg {
+					return
+				}
+				m := moduleNames[refPkg]
+				if !slices.Contains(imports[m], t.Name) {
+					imports[m] = append(imports[m], t.Name)
+				}
+			})
+		}
+
+		sb := &strings.Builder{}
+		w := typingsWriter{
+			out:     sb,
+			opts:    &opts,
+			imports: imports,
+		}
+		if err := w.Write(declsByPkg[pkg]); err != nil {
+			return nil, err
+		}
+		result[moduleNames[pkg]+".d.ts"] = sb.String()
+	}
+	return result, nil
+}
+
+// Returns module names by package path. Name is last element of package path, if names collide more elements are used (eg. app-models).
+func getModuleNames(pkgs []string) map[string]string {
+	nameOf := func(pkgPath string, elems int) string {
+		parts := strings.Split(pkgPath, "/")
+		return strings.Join(parts[max(0, len(parts)-elems):], "-")
+	}
+
+	result := map[string]string{}
+	for _, p := range pkgs {
+		elems := 1
+		for ; elems < len(strings.Split(p, "/")); elems++ {
+			name := nameOf(p, elems)
+			if !slices.ContainsFunc(pkgs, func(other string) bool { return other != p && nameOf(other, elems) == name }) {
+				break
+			}
+		}
+		result[p] = nameOf(p, elems)
+	}
+	return result
+}
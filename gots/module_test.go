@@ -0,0 +1,85 @@
+package gots_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+func Test_Modules(t *testing.T) {
+	modules, err := gots.NewGenerator(gots.WithPackages("github.com/michal-laskowski/wax-libs/gots/testdata")).
+		GenerateModulesFromSource([]string{"./testdata/billing"}, "Invoice")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := map[string]string{
+		"billing.d.ts": `
+import type { Contact, Status } from "./models"
+import type { ShippingAddress, ShippingMethod } from "./shipping"
+
+/** Invoice issued to user. */
+export type Invoice = {
+  Number: string
+  Customer: Contact
+  Status: Status
+  Address: BillingAddress
+  Shipping: Shipment
+}
+
+/** Address of customer. */
+export type BillingAddress = {
+  Street: string
+  Zip: string
+}
+
+/** Shipment of invoiced goods. */
+export type Shipment = {
+  Address: ShippingAddress
+  Method: ShippingMethod
+}
+`,
+		"shipping.d.ts": `
+/** Address where order is shipped. */
+export type ShippingAddress = {
+  Street: string
+  City: string
+}
+
+/** Method of delivery. */
+export type ShippingMethod = {
+  Carrier: string
+}
+`,
+		"models.d.ts": `
+/** Contact of user. */
+export type Contact = {
+  /** Name to display. */
+  name: string
+  /** Email address. */
+  email?: string
+}
+
+/** Status of user account. */
+export type Status = "active" | "disabled" | "unknown"
+export declare const Status: {
+  /** StatusActive is status of active account. */
+  readonly Active: "active"
+  /** Account was disabled. */
+  readonly Disabled: "disabled"
+}
+`,
+	}
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		if a, e := strings.TrimSpace(modules[name]), strings.TrimSpace(expected[name]); a != e {
+			t.Errorf("Module %s not as expected:\n%v", name, diff.LineDiff(e, a))
+		}
+	}
+	if len(modules) != len(expected) {
+		t.Errorf("Expected %d modules, got %d", len(expected), len(modules))
+	}
+}
@@ -0,0 +1,131 @@
+package gots
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Writes types in nested namespace per go package (eg. billing.Address), references are qualified with namespace.
+func WithPackageNamespaces() Option {
+	return func(o *options) {
+		o.packageNamespaces = true
+	}
+}
+
+// Sets function returning prefix of types with colliding names.
+//
+// By default types with the same name from different packages are prefixed with package name (eg. BillingAddress and ShippingAddress).
+// Function gets package path and unique name of package (last elements of package path joined with "-").
+func WithNamePrefix(prefix func(pkgPath string, pkgName string) string) Option {
+	return func(o *options) {
+		o.namePrefix = prefix
+	}
+}
+
+// Writes type of given sample with given name. Source based generator uses //gots:name directive instead.
+func WithRename(sample any, name string) Option {
+	return func(o *options) {
+		o.renames[getTypeID(getUnderlyingType(reflect.TypeOf(sample)))] = name
+	}
+}
+
+// Default prefix of colliding types, package name in pascal case (eg. app-models -> AppModels).
+func defaultNamePrefix(pkgPath string, pkgName string) string {
+	sb := strings.Builder{}
+	upper := true
+	for _, c := range pkgName {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// Returns name of namespace for package, unique name of package as valid identifier.
+func getNamespaceName(pkgName string) string {
+	return strings.Map(func(c rune) rune {
+		if c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) {
+			return c
+		}
+		return '_'
+	}, pkgName)
+}
+
+// Resolves names of declarations: applies renames, prefixes colliding names (or qualifies them with package namespace) and updates references.
+//
+// Returns names used in references by declaration ID.
+func (o *options) resolveNames(decls []*tsDecl) map[string]string {
+	for _, d := range decls {
+		if name, ok := o.renames[d.ID]; ok {
+			d.Name = name
+			d.ExplicitName = true
+		}
+	}
+	pkgNames := getModuleNames(getDeclPackages(decls))
+
+	refNames := map[string]string{}
+	if o.packageNamespaces {
+		for _, d := range decls {
+			refNames[d.ID] = getNamespaceName(pkgNames[d.PkgPath]) + "." + d.Name
+		}
+	} else {
+		byName := map[string][]*tsDecl{}
+		for _, d := range decls {
+			byName[d.Name] = append(byName[d.Name], d)
+		}
+		prefix := o.namePrefix
+		if prefix == nil {
+			prefix = defaultNamePrefix
+		}
+		for _, d := range decls {
+			if len(byName[d.Name]) > 1 && !d.ExplicitName {
+				refNames[d.ID] = prefix(d.PkgPath, pkgNames[d.PkgPath]) + d.Name
+			} else {
+				refNames[d.ID] = d.Name
+			}
+		}
+		for _, d := range decls {
+			d.Name = refNames[d.ID]
+		}
+	}
+
+	updateRefs(decls, refNames, "")
+	return refNames
+}
+
+// Updates names in references to declarations, names are qualified with given namespace.
+func updateRefs(decls []*tsDecl, refNames map[string]string, namespace string) {
+	update := func(t *tsType) {
+		if name, ok := refNames[t.Ref]; ok && t.Kind == tsRef {
+			if namespace != "" {
+				name = namespace + "." + name
+			}
+			t.Name = name
+		}
+	}
+	for _, d := range decls {
+		d.walkTypes(update)
+		for _, i := range d.Instances {
+			i.walk(update)
+		}
+	}
+}
+
+// Returns package paths of declarations in order of first declaration.
+func getDeclPackages(decls []*tsDecl) []string {
+	pkgs := []string{}
+	for _, d := range decls {
+		if !slices.Contains(pkgs, d.PkgPath) {
+			pkgs = append(pkgs, d.PkgPath)
+		}
+	}
+	return pkgs
+}
@@ -0,0 +1,124 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+	crm "github.com/michal-laskowski/wax-libs/gots/testdata/crm/models"
+	erp "github.com/michal-laskowski/wax-libs/gots/testdata/erp/models"
+)
+
+func Test_NamePrefix(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(
+		gots.WithPackages("github.com/michal-laskowski/wax-libs/gots/testdata/billing", "github.com/michal-laskowski/wax-libs/gots/testdata/shipping"),
+		gots.WithNamePrefix(func(pkgPath string, pkgName string) string { return strings.ToUpper(pkgName[:1]) }),
+	).GenerateFromSource(buf, []string{"./testdata/billing"}, "Shipment", "Address")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** Shipment of invoiced goods. */
+type Shipment = {
+  Address: SAddress
+  Method: ShippingMethod
+}
+
+/** Address of customer. */
+type BAddress = {
+  Street: string
+  Zip: string
+}
+
+/** Address where order is shipped. */
+type SAddress = {
+  Street: string
+  City: string
+}
+
+/** Method of delivery. */
+type ShippingMethod = {
+  Carrier: string
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+type DummySamePackageNames struct {
+	Customer  crm.Address
+	Warehouse erp.Address
+}
+
+func Test_SamePackageNames(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages("github.com/michal-laskowski/wax-libs/gots")).
+		Add(DummySamePackageNames{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type DummySamePackageNames = {
+  Customer: CrmModelsAddress
+  Warehouse: ErpModelsAddress
+}
+
+type CrmModelsAddress = {
+  Street: string
+}
+
+type ErpModelsAddress = {
+  Code: number
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_PackageNamespaces(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithPackageNamespaces(), gots.WithRename(Address{}, "Location"), gots.WithMethods(false)).
+		Add(Author{}).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+declare namespace gots_test {
+  type Author = {
+    Name: string
+    Alias: gots_test.StringAlias
+    Address: gots_test.Location
+  } & gots_test.Contact
+
+  type Contact = {
+    Contact: string
+    Email: string
+  }
+
+  type StringAlias = object & { 
+  }
+
+  type Location = {
+    City: string
+  }
+
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
@@ -0,0 +1,325 @@
+package gots
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Generates JSON Schema (draft 2020-12) for given types, each named type is written in $defs.
+//
+// If pkg is specified it will generate types in packages containing given prefix.
+//
+// Use Generator for more options.
+func GenerateJSONSchema(out io.StringWriter, pkg string, typesToGenerate ...any) error {
+	return NewGenerator(WithPackages(pkg)).
+		Add(typesToGenerate...).
+		GenerateJSONSchema(out)
+}
+
+// Generates JSON Schema (draft 2020-12) for registered types, each named type is written in $defs (eg. "#/$defs/Contact").
+//
+// Types are described as serialized by encoding/json: pointers are nullable, slices are arrays, maps are objects with additionalProperties.
+// Instances of generic types are written as separate definitions (eg. Page<Contact> as Page_Contact), registered instances (eg. Page[Contact]{}) too.
+func (g *Generator) GenerateJSONSchema(out io.StringWriter) error {
+	sg := *g
+	sg.profile = RuntimeJSON.Profile()
+	sg.fieldNameMapper = nil
+	sg.packageNamespaces = false
+	decls, err := sg.generateDecls()
+	g.reported = sg.reported
+	if err != nil {
+		return err
+	}
+	sg.resolveNames(decls)
+
+	w := schemaWriter{
+		declsByID: map[string]*tsDecl{},
+		defs:      &jsonObject{},
+		written:   map[string]bool{},
+	}
+	for _, d := range decls {
+		w.declsByID[d.ID] = d
+	}
+	for _, d := range decls {
+		if len(d.TypeParams) == 0 {
+			w.writeDef(d.Name, d, nil)
+		}
+		for _, i := range d.Instances {
+			w.typeSchema(i, nil)
+		}
+	}
+
+	schema := &jsonObject{}
+	schema.set("$schema", jsonSchemaDraft)
+	if lines := sg.headerLines(decls); len(lines) > 0 {
+		schema.set("$comment", strings.Join(lines, "\n"))
+	}
+	schema.set("$defs", w.defs)
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, data, "", strings.Repeat(" ", g.indentWidth)); err != nil {
+		return err
+	}
+	buf.WriteString("\n")
+	_, err = out.WriteString(buf.String())
+	return err
+}
+
+// schemaWriter writes declarations as JSON Schema definitions.
+type schemaWriter struct {
+	declsByID map[string]*tsDecl
+	defs      *jsonObject
+	// names of written definitions
+	written map[string]bool
+}
+
+// Writes definition of declaration, type arguments replace type parameters of generic declaration.
+func (w *schemaWriter) writeDef(name string, d *tsDecl, args map[string]*tsType) {
+	if w.written[name] {
+		return
+	}
+	w.written[name] = true
+	// keeps order of definitions, nested instances of generic types are written after
+	w.defs.set(name, nil)
+
+	var def *jsonObject
+	switch {
+	case len(d.Values) > 0:
+		def = &jsonObject{}
+		values := []any{}
+		for _, v := range d.Values {
+			values = append(values, getSchemaValue(v.Value))
+		}
+		def.set("enum", values)
+	case d.Type != nil && len(d.Members) == 0:
+		def = w.typeSchema(d.Type, args)
+	default:
+		def = w.objectSchema(d.Members, d.Extends, args)
+	}
+	if doc := strings.TrimSpace(d.Doc); doc != "" {
+		def.set("description", doc)
+	}
+	w.defs.set(name, def)
+}
+
+func (w *schemaWriter) objectSchema(members []*tsMember, extends []*tsType, args map[string]*tsType) *jsonObject {
+	result := &jsonObject{}
+	result.set("type", "object")
+	properties := &jsonObject{}
+	required := []string{}
+	for _, m := range members {
+		if m.IsMethod || m.Comment != "" {
+			continue
+		}
+		p := w.typeSchema(m.Type, args)
+		if doc := strings.TrimSpace(m.Doc); doc != "" {
+			p.set("description", doc)
+		}
+		properties.set(m.Name, p)
+		if !m.Optional {
+			required = append(required, m.Name)
+		}
+	}
+	result.set("properties", properties)
+	if len(required) > 0 {
+		result.set("required", required)
+	}
+	if len(extends) == 0 {
+		return result
+	}
+
+	allOf := []any{}
+	for _, e := range extends {
+		allOf = append(allOf, w.typeSchema(e, args))
+	}
+	allOf = append(allOf, result)
+	return (&jsonObject{}).set("allOf", allOf)
+}
+
+func (w *schemaWriter) typeSchema(t *tsType, args map[string]*tsType) *jsonObject {
+	result := &jsonObject{}
+	switch t.Kind {
+	case tsKeyword:
+		switch t.Name {
+		case "string", "number", "boolean", "object", "null":
+			result.set("type", t.Name)
+		}
+	case tsTypeParam:
+		if a, ok := args[t.Name]; ok {
+			return w.typeSchema(a, nil)
+		}
+	case tsNullable:
+		elem := w.typeSchema(t.Args[0], args)
+		if typeName, ok := elem.get("type").(string); ok && len(*elem) == 1 {
+			result.set("type", []string{typeName, "null"})
+		} else {
+			result.set("anyOf", []any{elem, (&jsonObject{}).set("type", "null")})
+		}
+	case tsArray:
+		result.set("type", "array")
+		result.set("items", w.typeSchema(t.Args[0], args))
+	case tsTuple:
+		items := []any{}
+		for _, e := range t.Args {
+			items = append(items, w.typeSchema(e, args))
+		}
+		result.set("type", "array")
+		result.set("prefixItems", items)
+		result.set("minItems", len(items))
+		result.set("maxItems", len(items))
+	case tsRecord:
+		result.set("type", "object")
+		result.set("additionalProperties", w.typeSchema(t.Args[1], args))
+	case tsMapped:
+		result.set("type", "object")
+		result.set("propertyNames", w.typeSchema(t.Args[0], args))
+		result.set("additionalProperties", w.typeSchema(t.Args[1], args))
+	case tsUnion:
+		oneOf := []any{}
+		for _, e := range t.Args {
+			oneOf = append(oneOf, w.typeSchema(e, args))
+		}
+		result.set("oneOf", oneOf)
+	case tsLiteral:
+		result.set("const", getSchemaValue(t.Name))
+	case tsObject:
+		return w.objectSchema(t.Members, t.Extends, args)
+	case tsRef:
+		d, ok := w.declsByID[t.Ref]
+		if !ok {
+			// not generated (excluded) type
+			break
+		}
+		name := t.Name
+		if len(t.Args) > 0 {
+			instanceArgs := map[string]*tsType{}
+			for i, a := range t.Args {
+				a = w.resolveTypeArg(a, args)
+				if i < len(d.TypeParams) {
+					instanceArgs[d.TypeParams[i]] = a
+				}
+				name += "_" + getSchemaTypeName(a)
+			}
+			w.writeDef(name, d, instanceArgs)
+		}
+		result.set("$ref", "#/$defs/"+name)
+	}
+	return result
+}
+
+// Replaces type parameters in type argument with their values.
+func (w *schemaWriter) resolveTypeArg(t *tsType, args map[string]*tsType) *tsType {
+	if t.Kind == tsTypeParam {
+		if a, ok := args[t.Name]; ok {
+			return a
+		}
+		return t
+	}
+	if len(t.Args) == 0 {
+		return t
+	}
+	resolved := *t
+	resolved.Args = nil
+	for _, a := range t.Args {
+		resolved.Args = append(resolved.Args, w.resolveTypeArg(a, args))
+	}
+	return &resolved
+}
+
+// Returns name of type used in names of generic instances (eg. Page_Contact, Page_string_Array).
+func getSchemaTypeName(t *tsType) string {
+	switch t.Kind {
+	case tsNullable:
+		return "Nullable_" + getSchemaTypeName(t.Args[0])
+	case tsArray:
+		return getSchemaTypeName(t.Args[0]) + "_Array"
+	case tsRecord, tsMapped:
+		return "Record_" + getSchemaTypeName(t.Args[0]) + "_" + getSchemaTypeName(t.Args[1])
+	case tsTuple:
+		name := "Tuple"
+		for _, e := range t.Args {
+			name += "_" + getSchemaTypeName(e)
+		}
+		return name
+	case tsUnion:
+		name := "Union"
+		for _, e := range t.Args {
+			name += "_" + getSchemaTypeName(e)
+		}
+		return name
+	case tsObject:
+		return "object"
+	}
+	name := t.Name
+	for _, a := range t.Args {
+		name += "_" + getSchemaTypeName(a)
+	}
+	return name
+}
+
+// Returns value for typescript literal.
+func getSchemaValue(literal string) any {
+	if s, err := strconv.Unquote(literal); err == nil {
+		return s
+	}
+	return json.RawMessage(literal)
+}
+
+// jsonObject is JSON object keeping order of properties.
+type jsonObject []jsonProperty
+
+type jsonProperty struct {
+	Name  string
+	Value any
+}
+
+func (o *jsonObject) set(name string, value any) *jsonObject {
+	for i, p := range *o {
+		if p.Name == name {
+			(*o)[i].Value = value
+			return o
+		}
+	}
+	*o = append(*o, jsonProperty{name, value})
+	return o
+}
+
+func (o *jsonObject) get(name string) any {
+	for _, p := range *o {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return nil
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("{")
+	for i, p := range o {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		name, err := json.Marshal(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(p.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,208 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type SchemaOrder struct {
+	Contact
+	ID       int            `json:"id"`
+	Note     *string        `json:"note,omitempty"`
+	Tags     []string       `json:"tags,omitempty"`
+	Lines    map[string]int `json:"lines"`
+	Status   AccountStatus  `json:"status"`
+	Alias    StringAlias    `json:"alias"`
+	Contacts Page[Contact]  `json:"contacts"`
+	Secret   string         `json:"-"`
+}
+
+func Test_JSONSchema(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateJSONSchema(buf, thisPackageOnly(), SchemaOrder{}, gots.Enum(AccountActive, AccountDisabled))
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "SchemaOrder": {
+      "allOf": [
+        {
+          "$ref": "#/$defs/Contact"
+        },
+        {
+          "type": "object",
+          "properties": {
+            "id": {
+              "type": "number"
+            },
+            "note": {
+              "type": [
+                "string",
+                "null"
+              ]
+            },
+            "tags": {
+              "type": "array",
+              "items": {
+                "type": "string"
+              }
+            },
+            "lines": {
+              "anyOf": [
+                {
+                  "type": "object",
+                  "additionalProperties": {
+                    "type": "number"
+                  }
+                },
+                {
+                  "type": "null"
+                }
+              ]
+            },
+            "status": {
+              "$ref": "#/$defs/AccountStatus"
+            },
+            "alias": {
+              "$ref": "#/$defs/StringAlias"
+            },
+            "contacts": {
+              "$ref": "#/$defs/Page_Contact"
+            }
+          },
+          "required": [
+            "id",
+            "lines",
+            "status",
+            "alias",
+            "contacts"
+          ]
+        }
+      ]
+    },
+    "Page_Contact": {
+      "type": "object",
+      "properties": {
+        "Items": {
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "$ref": "#/$defs/Contact"
+              }
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "Total": {
+          "type": "number"
+        }
+      },
+      "required": [
+        "Items",
+        "Total"
+      ]
+    },
+    "AccountStatus": {
+      "enum": [
+        "active",
+        "disabled"
+      ]
+    },
+    "Contact": {
+      "type": "object",
+      "properties": {
+        "Contact": {
+          "type": "string"
+        },
+        "Email": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "Contact",
+        "Email"
+      ]
+    },
+    "StringAlias": {
+      "type": "string"
+    }
+  }
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
+
+func Test_JSONSchemaGenericRoot(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.GenerateJSONSchema(buf, thisPackageOnly(), Page[Contact]{})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "Page_Contact": {
+      "type": "object",
+      "properties": {
+        "Items": {
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "$ref": "#/$defs/Contact"
+              }
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "Total": {
+          "type": "number"
+        }
+      },
+      "required": [
+        "Items",
+        "Total"
+      ]
+    },
+    "Contact": {
+      "type": "object",
+      "properties": {
+        "Contact": {
+          "type": "string"
+        },
+        "Email": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "Contact",
+        "Email"
+      ]
+    }
+  }
+}
+`
+	actual := buf.String()
+	if a, e := strings.TrimSpace(actual), strings.TrimSpace(expected); a != e {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(e, a))
+	}
+}
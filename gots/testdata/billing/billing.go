@@ -0,0 +1,38 @@
+// Package billing references types from other packages, used by tests of module output and naming.
+package billing
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/michal-laskowski/wax-libs/gots/testdata/models"
+	"github.com/michal-laskowski/wax-libs/gots/testdata/shipping"
+)
+
+// Invoice issued to user.
+type Invoice struct {
+	Number   string
+	Customer models.Contact
+	Status   models.Status
+	Address  Address
+	Shipping Shipment
+}
+
+// Address of customer.
+type Address struct {
+	Street string
+	Zip    string
+}
+
+// Shipment of invoiced goods.
+type Shipment struct {
+	Address shipping.Address
+	Method  shipping.Method
+}
+
+// Payment of invoice.
+type Payment struct {
+	Err   error
+	Body  io.Reader
+	State http.ConnState
+}
@@ -0,0 +1,33 @@
+// Package blocks declares discriminated union, used by tests of unions.
+package blocks
+
+// Block of page content.
+//
+//gots:union kind
+type Block interface {
+	isBlock()
+}
+
+// Paragraph of text.
+//
+//gots:variant text
+type TextBlock struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+func (TextBlock) isBlock() {}
+
+// Image with caption.
+//
+//gots:variant image
+type ImageBlock struct {
+	URL string `json:"url"`
+}
+
+func (*ImageBlock) isBlock() {}
+
+//gots:export
+type Page struct {
+	Blocks []Block `json:"blocks"`
+}
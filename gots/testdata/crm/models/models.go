@@ -0,0 +1,7 @@
+// Package models declares types with the same package and type names as erp/models, used by tests of naming.
+package models
+
+// Address of customer.
+type Address struct {
+	Street string
+}
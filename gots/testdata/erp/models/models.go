@@ -0,0 +1,7 @@
+// Package models declares types with the same package and type names as crm/models, used by tests of naming.
+package models
+
+// Address of warehouse.
+type Address struct {
+	Code int
+}
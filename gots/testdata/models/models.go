@@ -0,0 +1,112 @@
+// Package models contains types used by tests of source generator.
+package models
+
+import "time"
+
+// Contact of user.
+type Contact struct {
+	// Name to display.
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"` // Email address.
+	internal string
+}
+
+// Status of user account.
+//
+//gots:const
+type Status string
+
+const (
+	// StatusActive is status of active account.
+	StatusActive   Status = "active"
+	StatusDisabled Status = "disabled" // Account was disabled.
+	statusUnknown  Status = "unknown"
+)
+
+// Role of user.
+type Role int
+
+const (
+	RoleUser Role = iota + 1
+	RoleAdmin
+)
+
+// User account.
+//
+// Used by views.
+//
+//gots:export
+type User struct {
+	Contact
+	ID       int
+	Status   Status
+	Role     Role
+	Friends  []*User
+	Contacts Page[Contact]
+}
+
+// DisplayName returns name to display.
+func (u *User) DisplayName(separator string, parts ...string) string {
+	return u.Name
+}
+
+// Page of items.
+type Page[T any] struct {
+	// Items on page.
+	Items []T
+	Total int
+}
+
+// Notifier sends notifications.
+type Notifier interface {
+	// Notify sends message to user.
+	Notify(user *User, message string) bool
+}
+
+// Stats of accounts.
+type Stats struct {
+	ByStatus map[Status]int
+	ByRole   map[Role]int
+	ByDay    map[int]int
+}
+
+// Top returns most common status and number of accounts with it.
+func (s *Stats) Top() (Status, int, error) {
+	return StatusActive, 0, nil
+}
+
+// Level of logging.
+type Level int
+
+// DefaultLevel is used when level is not set.
+const DefaultLevel Level = 3
+
+// Priority of task.
+//
+//gots:enum
+type Priority int
+
+// PriorityHigh is the only predefined priority.
+const PriorityHigh Priority = 10
+
+// Schedule of reports.
+type Schedule struct {
+	Every time.Duration
+	Month time.Month
+}
+
+// Member of team.
+type Member struct {
+	Contact `json:"contact"`
+	Team    string `json:"team"`
+}
+
+type audit struct {
+	CreatedBy string
+}
+
+// Order of user.
+type Order struct {
+	audit
+	Number string
+}
@@ -0,0 +1,15 @@
+// Package shipping declares types with the same names as other packages, used by tests of naming.
+package shipping
+
+// Address where order is shipped.
+type Address struct {
+	Street string
+	City   string
+}
+
+// Method of delivery.
+//
+//gots:name ShippingMethod
+type Method struct {
+	Carrier string
+}
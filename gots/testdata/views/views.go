@@ -0,0 +1,17 @@
+// Package views declares models of views, used by tests of views.
+package views
+
+// Model of list of users.
+//
+//gots:view users/list
+type UsersList struct {
+	Users []User
+}
+
+// User shown in views.
+//
+//gots:view users/edit
+//gots:view users/show
+type User struct {
+	Name string
+}
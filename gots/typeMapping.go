@@ -0,0 +1,149 @@
+package gots
+
+import (
+	"encoding"
+	"encoding/json"
+	"go/types"
+	"maps"
+	"reflect"
+)
+
+// TypeMapping maps go types to typescript types.
+//
+// Mapped types are not generated, references to them are replaced with given typescript type.
+type TypeMapping struct {
+	// typescript type by package path and name of go type
+	types          map[string]string
+	textMarshalers string
+	jsonMarshalers string
+}
+
+// TypeMappings are used by GenerateTypeDefinition and GenerateTypeDefinitionFromSource.
+//
+// Initialized with DefaultTypeMapping, register mappings for third party types here.
+var TypeMappings = DefaultTypeMapping()
+
+// Creates empty type mapping.
+func NewTypeMapping() *TypeMapping {
+	return &TypeMapping{
+		types: map[string]string{},
+	}
+}
+
+// Creates type mapping with common types:
+//   - time.Time as string, time.Duration, time.Month and time.Weekday as number,
+//   - uuid.UUID (github.com/google/uuid) and decimal.Decimal (github.com/shopspring/decimal) as string,
+//   - types implementing encoding.TextMarshaler as string (if runtime profile uses marshalers).
+func DefaultTypeMapping() *TypeMapping {
+	return NewTypeMapping().
+		MapName("time.Time", "string").
+		MapName("time.Duration", "number").
+		MapName("time.Month", "number").
+		MapName("time.Weekday", "number").
+		MapName("github.com/google/uuid.UUID", "string").
+		MapName("github.com/shopspring/decimal.Decimal", "string").
+		MapTextMarshalers("string")
+}
+
+// Maps type of given sample (eg. uuid.UUID{}) to typescript type.
+func (m *TypeMapping) Map(sample any, tsType string) *TypeMapping {
+	return m.MapName(getTypeID(getUnderlyingType(reflect.TypeOf(sample))), tsType)
+}
+
+// Maps type with given package path and name (eg. "github.com/google/uuid.UUID") to typescript type.
+func (m *TypeMapping) MapName(name string, tsType string) *TypeMapping {
+	m.types[name] = tsType
+	return m
+}
+
+// Maps types implementing encoding.TextMarshaler to typescript type. Empty type disables mapping.
+//
+// Marshalers are mapped only if runtime profile uses them (see Profile.Marshalers), goja exposes go value instead.
+func (m *TypeMapping) MapTextMarshalers(tsType string) *TypeMapping {
+	m.textMarshalers = tsType
+	return m
+}
+
+// Maps types implementing json.Marshaler to typescript type. Empty type disables mapping.
+//
+// Takes precedence over encoding.TextMarshaler mapping.
+func (m *TypeMapping) MapJSONMarshalers(tsType string) *TypeMapping {
+	m.jsonMarshalers = tsType
+	return m
+}
+
+// Creates copy of type mapping.
+func (m *TypeMapping) Clone() *TypeMapping {
+	result := *m
+	result.types = maps.Clone(m.types)
+	return &result
+}
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	errorType         = reflect.TypeFor[error]()
+)
+
+func (m *TypeMapping) lookup(t reflect.Type, marshalers bool) (string, bool) {
+	if m == nil || t.Name() == "" || t.Kind() == reflect.Interface {
+		return "", false
+	}
+	if tsType, ok := m.types[getTypeID(t)]; ok {
+		return tsType, true
+	}
+	if !marshalers {
+		return "", false
+	}
+
+	implements := func(i reflect.Type) bool {
+		return t.Implements(i) || reflect.PointerTo(t).Implements(i)
+	}
+	if m.jsonMarshalers != "" && implements(jsonMarshalerType) {
+		return m.jsonMarshalers, true
+	}
+	if m.textMarshalers != "" && implements(textMarshalerType) {
+		return m.textMarshalers, true
+	}
+	return "", false
+}
+
+func (m *TypeMapping) lookupName(name string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	tsType, ok := m.types[name]
+	return tsType, ok
+}
+
+func (m *TypeMapping) lookupNamed(t *types.Named, marshalers bool) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	if _, isInterface := t.Underlying().(*types.Interface); isInterface {
+		return "", false
+	}
+	if tsType, ok := m.types[getNamedTypeID(t)]; ok {
+		return tsType, true
+	}
+	if !marshalers {
+		return "", false
+	}
+
+	methods := types.NewMethodSet(types.NewPointer(t))
+	implements := func(name string) bool {
+		sel := methods.Lookup(nil, name)
+		if sel == nil {
+			return false
+		}
+		sig := sel.Type().(*types.Signature)
+		return sig.Params().Len() == 0 && sig.Results().Len() == 2
+	}
+	if m.jsonMarshalers != "" && implements("MarshalJSON") {
+		return m.jsonMarshalers, true
+	}
+	if m.textMarshalers != "" && implements("MarshalText") {
+		return m.textMarshalers, true
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package gots
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Union registers implementations of interface T with values of their discriminator property, register it with Add.
+//
+// Interface is written as union of implementations, discriminator property of each implementation has literal type:
+//
+//	gots.Union[Block]("kind", gots.Variant("text", TextBlock{}), gots.Variant("image", ImageBlock{}))
+//
+//	type Block = TextBlock | ImageBlock
+//	type TextBlock = {
+//	  kind: "text"
+//	  ...
+//
+// Discriminator property is added to implementation which doesn't have it (eg. written by custom marshaler).
+// Source based generator uses //gots:union kind directive on interface and //gots:variant text directive on implementations instead.
+func Union[T any](discriminator string, variants ...UnionVariant) any {
+	return unionTypes{reflect.TypeFor[T](), discriminator, variants}
+}
+
+// UnionVariant is implementation of interface registered with Union.
+type UnionVariant struct {
+	value any
+	impl  any
+}
+
+// Returns implementation of union (type of impl) with given value of discriminator (eg. "text").
+func Variant(value any, impl any) UnionVariant {
+	return UnionVariant{value, impl}
+}
+
+type unionTypes struct {
+	iface         reflect.Type
+	discriminator string
+	variants      []UnionVariant
+}
+
+// discriminatorValue is value of discriminator property of union implementation.
+type discriminatorValue struct {
+	Name string
+	// Typescript literal.
+	Value string
+}
+
+// Writes declaration as discriminated union of given variants.
+func setUnion(decl *tsDecl, discriminator string, variants []*tsVariant) {
+	decl.Discriminator = discriminator
+	decl.Variants = variants
+	types := []*tsType{}
+	for _, v := range variants {
+		types = append(types, v.Type)
+	}
+	decl.Type = tsUnionType(types...)
+}
+
+// Sets literal types of discriminator properties of union implementation, missing properties are added.
+func setDiscriminators(decl *tsDecl, values []discriminatorValue) {
+	for _, v := range slices.Backward(values) {
+		i := slices.IndexFunc(decl.Members, func(m *tsMember) bool { return m.Name == v.Name && !m.IsMethod })
+		if i < 0 {
+			decl.Members = slices.Insert(decl.Members, 0, &tsMember{Name: v.Name})
+			i = 0
+		}
+		decl.Members[i].Type = tsLiteralType(v.Value)
+		decl.Members[i].Optional = false
+	}
+}
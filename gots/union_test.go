@@ -0,0 +1,160 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type Block interface {
+	isBlock()
+}
+
+type TextBlock struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+func (TextBlock) isBlock() {}
+
+type ImageBlock struct {
+	URL string `json:"url"`
+}
+
+func (*ImageBlock) isBlock() {}
+
+type BlockPage struct {
+	Main   Block   `json:"main"`
+	Blocks []Block `json:"blocks"`
+}
+
+func Test_Union(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	g := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithRuntime(gots.RuntimeJSON)).
+		Add(BlockPage{}, gots.Union[Block]("kind", gots.Variant("text", TextBlock{}), gots.Variant("image", &ImageBlock{})))
+	err := g.Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type BlockPage = {
+  main: null | Block
+  blocks: null | (null | Block)[]
+}
+
+type Block = TextBlock | ImageBlock
+
+type TextBlock = {
+  kind: "text"
+  text: string
+}
+
+type ImageBlock = {
+  kind: "image"
+  url: string
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	buf = bytes.NewBufferString("")
+	err = g.GenerateGuards(buf, "./models")
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	actual = buf.String()
+	_, actual, _ = strings.Cut(actual, "export function assertBlock(")
+	expected = `
+v: unknown, path: string = "Block"): asserts v is Block {
+  checkUnion(v, path, "kind", new Map<unknown, Check>([["text", assertTextBlock], ["image", assertImageBlock]]))
+}
+
+export function isBlock(v: unknown): v is Block {
+  try {
+    assertBlock(v)
+    return true
+  } catch {
+    return false
+  }
+}
+
+export function assertTextBlock(v: unknown, path: string = "TextBlock"): asserts v is TextBlock {
+  checkObject(v, path)
+  checkValues(v["kind"], path + ".kind", ["text"])
+  checkType(v["text"], "string", path + ".text")
+}
+
+export function isTextBlock(v: unknown): v is TextBlock {
+  try {
+    assertTextBlock(v)
+    return true
+  } catch {
+    return false
+  }
+}
+
+export function assertImageBlock(v: unknown, path: string = "ImageBlock"): asserts v is ImageBlock {
+  checkObject(v, path)
+  checkValues(v["kind"], path + ".kind", ["image"])
+  checkType(v["url"], "string", path + ".url")
+}
+
+export function isImageBlock(v: unknown): v is ImageBlock {
+  try {
+    assertImageBlock(v)
+    return true
+  } catch {
+    return false
+  }
+}
+`
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	err = gots.NewGenerator().Add(gots.Union[Block]("kind", gots.Variant("text", BlockPage{}))).Generate(bytes.NewBufferString(""))
+	if err == nil || err.Error() != "union gots_test.Block: gots_test.BlockPage doesn't implement it" {
+		t.Errorf("Expected error for invalid variant, got %v", err)
+	}
+}
+
+func Test_UnionFromSource(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages("github.com/michal-laskowski/wax-libs/gots/testdata/blocks")).
+		GenerateFromSource(buf, []string{"./testdata/blocks"})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type Page = {
+  blocks: (null | Block)[]
+}
+
+/** Block of page content. */
+type Block = TextBlock | ImageBlock
+
+/** Paragraph of text. */
+type TextBlock = {
+  kind: "text"
+  text: string
+}
+
+/** Image with caption. */
+type ImageBlock = {
+  kind: "image"
+  url: string
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
@@ -0,0 +1,71 @@
+package gots
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// View registers model type of wax view with given name (eg. View("users/list", UsersListModel{})), register it with Add.
+//
+// Views are written as WaxViews type mapping view names to props type of each view:
+//
+//	type UsersListProps = UsersListModel
+//	type WaxViews = {
+//	  "users/list": UsersListProps
+//	}
+//
+// Model of view without model is nil. Source based generator uses //gots:view users/list directive on model type instead.
+func View(name string, model any) any {
+	return viewModel{name, model}
+}
+
+type viewModel struct {
+	name  string
+	model any
+}
+
+// tsView is view with typescript type of its model.
+type tsView struct {
+	Name  string
+	Model *tsType
+}
+
+// Package path of declarations of views.
+const viewsPkgPath = "wax/views"
+
+// Returns declarations of props of views and WaxViews type, views are sorted by name.
+func getViewDecls(views []tsView) ([]*tsDecl, error) {
+	if len(views) == 0 {
+		return nil, nil
+	}
+	views = slices.SortedStableFunc(slices.Values(views), func(a, b tsView) int { return strings.Compare(a.Name, b.Name) })
+
+	result := []*tsDecl{}
+	waxViews := &tsDecl{
+		ID:      viewsPkgPath + ".WaxViews",
+		PkgPath: viewsPkgPath,
+		Name:    "WaxViews",
+		Doc:     "Props of wax views by view name.",
+		Root:    true,
+	}
+	for i, v := range views {
+		if i > 0 && views[i-1].Name == v.Name {
+			return nil, fmt.Errorf("view %s is registered more than once", v.Name)
+		}
+		props := &tsDecl{
+			ID:      viewsPkgPath + ".view:" + v.Name,
+			PkgPath: viewsPkgPath,
+			Name:    defaultNamePrefix("", v.Name) + "Props",
+			Doc:     fmt.Sprintf("Props of view %s.", v.Name),
+			Root:    true,
+			Type:    v.Model,
+		}
+		result = append(result, props)
+		waxViews.Members = append(waxViews.Members, &tsMember{
+			Name: v.Name,
+			Type: tsRefType(props.ID, props.Name),
+		})
+	}
+	return append(result, waxViews), nil
+}
@@ -0,0 +1,149 @@
+package gots_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/michal-laskowski/wax-libs/gots"
+)
+
+type UsersListModel struct {
+	Users []ViewUser
+	Page  int
+}
+
+type ViewUser struct {
+	Name string
+}
+
+func Test_Views(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly())).
+		Add(
+			gots.View("users/list", UsersListModel{}),
+			gots.View("users/edit", &ViewUser{}),
+			gots.View("home", nil),
+		).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type UsersListModel = {
+  Users: ViewUser[]
+  Page: number
+}
+
+type ViewUser = {
+  Name: string
+}
+
+/** Props of view home. */
+type HomeProps = undefined
+
+/** Props of view users/edit. */
+type UsersEditProps = ViewUser
+
+/** Props of view users/list. */
+type UsersListProps = UsersListModel
+
+/** Props of wax views by view name. */
+type WaxViews = {
+  home: HomeProps
+  "users/edit": UsersEditProps
+  "users/list": UsersListProps
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+
+	err = gots.NewGenerator(gots.WithPackages(thisPackageOnly())).
+		Add(gots.View("home", nil), gots.View("home", ViewUser{})).
+		Generate(bytes.NewBufferString(""))
+	if err == nil || err.Error() != "view home is registered more than once" {
+		t.Errorf("Expected error for duplicated view, got %v", err)
+	}
+}
+
+func Test_ViewsFromSource(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages("github.com/michal-laskowski/wax-libs/gots/testdata/views"), gots.WithOrder(gots.OrderRootsFirst)).
+		GenerateFromSource(buf, []string{"./testdata/views"})
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+/** User shown in views. */
+type User = {
+  Name: string
+}
+
+/** Props of view users/edit. */
+type UsersEditProps = User
+
+/** Model of list of users. */
+type UsersList = {
+  Users: User[]
+}
+
+/** Props of view users/list. */
+type UsersListProps = UsersList
+
+/** Props of view users/show. */
+type UsersShowProps = User
+
+/** Props of wax views by view name. */
+type WaxViews = {
+  "users/edit": UsersEditProps
+  "users/list": UsersListProps
+  "users/show": UsersShowProps
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
+
+func Test_ViewsUnnamedModels(t *testing.T) {
+	buf := bytes.NewBufferString("")
+
+	err := gots.NewGenerator(gots.WithPackages(thisPackageOnly()), gots.WithStrict()).
+		Add(
+			gots.View("contacts/list", []Contact{}),
+			gots.View("contacts/byEmail", map[string]*Contact{}),
+		).
+		Generate(buf)
+	if err != nil {
+		t.Errorf("\n-----------------------\n !!!!!!!!!!!!!! Errored - %+v", err)
+	}
+	expected := `
+type Contact = {
+  Contact: string
+  Email: string
+}
+
+/** Props of view contacts/byEmail. */
+type ContactsByEmailProps = Record<string, null | Contact>
+
+/** Props of view contacts/list. */
+type ContactsListProps = Contact[]
+
+/** Props of wax views by view name. */
+type WaxViews = {
+  "contacts/byEmail": ContactsByEmailProps
+  "contacts/list": ContactsListProps
+}
+`
+	actual := buf.String()
+	if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+		t.Errorf("Result not as expected:\n%v", diff.LineDiff(expected, actual))
+	}
+}
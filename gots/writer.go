@@ -0,0 +1,387 @@
+package gots
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// typingsWriter writes declarations as typescript typings (.d.ts).
+type typingsWriter struct {
+	out    io.StringWriter
+	indent int
+	opts   *options
+	// imported type names by module (written in module mode)
+	imports map[string][]string
+	// declarations are written inside namespace
+	inNamespace bool
+	// declarations of globals, written after types
+	globals []*tsDecl
+}
+
+// JSDoc of functions throwing error.
+const throwsDoc = "@throws error returned by go function"
+
+func (w *typingsWriter) Write(decls []*tsDecl) error {
+	w.writeHeader(slices.Concat(decls, w.globals))
+	if len(w.imports) > 0 {
+		modules := slices.Sorted(maps.Keys(w.imports))
+		for _, m := range modules {
+			names := slices.Sorted(slices.Values(w.imports[m]))
+			w.outLine(fmt.Sprintf("import type { %s } from %s", strings.Join(names, ", "), strconv.Quote("./"+m)))
+		}
+		w.outEndLine()
+	}
+	if w.opts.namespace != "" {
+		w.outLine("declare namespace " + w.opts.namespace + " {")
+		w.doIndent()
+		w.inNamespace = true
+	}
+	if w.opts.packageNamespaces {
+		w.writePackageNamespaces(decls)
+	} else {
+		for _, d := range decls {
+			w.writeDecl(d)
+			w.outEndLine()
+		}
+	}
+	if w.opts.namespace != "" {
+		w.doDeIndent()
+		w.outLine("}")
+		if len(w.globals) > 0 {
+			w.outEndLine()
+		}
+	}
+	w.writeGlobals()
+	return nil
+}
+
+// Writes declarations of globals, in module they are declared in global scope.
+func (w *typingsWriter) writeGlobals() {
+	if len(w.globals) == 0 {
+		return
+	}
+	declare := "declare "
+	if w.exportKeyword() != "" {
+		w.outLine("declare global {")
+		w.doIndent()
+		declare = ""
+	}
+	for _, d := range w.globals {
+		w.writeGlobal(d, declare)
+		w.outEndLine()
+	}
+	if declare == "" {
+		w.doDeIndent()
+		w.outLine("}")
+	}
+}
+
+func (w *typingsWriter) writeGlobal(d *tsDecl, declare string) {
+	if d.Type.Kind != tsFunc {
+		w.writeDoc(d.Doc)
+		w.outLine(fmt.Sprintf("%sconst %s: %s", declare, d.Name, w.typeString(d.Type)))
+		return
+	}
+	doc := d.Doc
+	if d.Type.Throws {
+		doc = strings.TrimSpace(doc) + "\n\n" + throwsDoc
+	}
+	w.writeDoc(doc)
+	w.outLine(fmt.Sprintf("%sfunction %s(%s): %s", declare, d.Name, w.paramsString(d.Type.Params), w.resultString(d.Type.Result)))
+}
+
+func (w *typingsWriter) writeHeader(decls []*tsDecl) {
+	lines := w.opts.headerLines(decls)
+	for _, h := range lines {
+		w.outLine(strings.TrimRight("// "+h, " "))
+	}
+	if len(lines) > 0 {
+		w.outEndLine()
+	}
+}
+
+// Writes declarations in namespace per go package.
+func (w *typingsWriter) writePackageNamespaces(decls []*tsDecl) {
+	pkgs := getDeclPackages(decls)
+	pkgNames := getModuleNames(pkgs)
+	keyword := "namespace"
+	if !w.inNamespace {
+		keyword = "declare namespace"
+	}
+	inNamespace := w.inNamespace
+	for _, pkg := range pkgs {
+		w.outLine(fmt.Sprintf("%s %s {", keyword, getNamespaceName(pkgNames[pkg])))
+		w.doIndent()
+		w.inNamespace = true
+		for _, d := range decls {
+			if d.PkgPath == pkg {
+				w.writeDecl(d)
+				w.outEndLine()
+			}
+		}
+		w.doDeIndent()
+		w.inNamespace = inNamespace
+		w.outLine("}")
+		w.outEndLine()
+	}
+}
+
+func (w *typingsWriter) outLine(v string) {
+	w.out.WriteString(w.indentString())
+	w.out.WriteString(v)
+	w.out.WriteString("\n")
+}
+
+func (w *typingsWriter) indentString() string {
+	return strings.Repeat(" ", w.indent*w.opts.indentWidth)
+}
+
+func (w *typingsWriter) doIndent() {
+	w.indent++
+}
+
+func (w *typingsWriter) doDeIndent() {
+	w.indent--
+}
+
+func (w *typingsWriter) outNext(v string) {
+	w.out.WriteString(v)
+}
+
+func (w *typingsWriter) outEndLine() {
+	w.out.WriteString("\n")
+}
+
+func (w *typingsWriter) writeDecl(d *tsDecl) {
+	w.writeDoc(d.Doc)
+	if len(d.Values) > 0 {
+		w.writeValues(d)
+		return
+	}
+	if d.Type != nil && len(d.Members) == 0 {
+		w.outLine(fmt.Sprintf("%stype %s = %s", w.exportKeyword(), d.Name, w.typeString(d.Type)))
+		return
+	}
+
+	name := d.Name
+	if len(d.TypeParams) > 0 {
+		name += "<" + strings.Join(d.TypeParams, ", ") + ">"
+	}
+	if w.canWriteInterface(d) {
+		extends := []string{}
+		for _, e := range d.Extends {
+			extends = append(extends, w.typeString(e))
+		}
+		if len(extends) > 0 {
+			w.outLine(fmt.Sprintf("%sinterface %s extends %s {", w.exportKeyword(), name, strings.Join(extends, ", ")))
+		} else {
+			w.outLine(fmt.Sprintf("%sinterface %s {", w.exportKeyword(), name))
+		}
+		w.doIndent()
+		for _, m := range d.Members {
+			w.writeMember(m)
+		}
+		w.doDeIndent()
+		w.outLine("}")
+		return
+	}
+
+	if d.IsAliasObject {
+		w.outLine(fmt.Sprintf("%stype %s = %s & { ", w.exportKeyword(), name, "object"))
+	} else {
+		w.outLine(fmt.Sprintf("%stype %s = {", w.exportKeyword(), name))
+	}
+	w.doIndent()
+	for _, m := range d.Members {
+		w.writeMember(m)
+	}
+	w.doDeIndent()
+
+	w.outNext(w.indentString() + "}")
+	for _, e := range d.Extends {
+		w.outNext(" & " + w.typeString(e))
+	}
+	w.outEndLine()
+}
+
+// Interface can extend only declared types, aliases to base types are written as intersection with object.
+func (w *typingsWriter) canWriteInterface(d *tsDecl) bool {
+	if !w.opts.interfaces || d.IsAliasObject {
+		return false
+	}
+	for _, e := range d.Extends {
+		if e.Kind != tsRef {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *typingsWriter) exportKeyword() string {
+	if w.opts.export || w.opts.module {
+		return "export "
+	}
+	return ""
+}
+
+func (w *typingsWriter) writeValues(d *tsDecl) {
+	literals := []string{}
+	for _, v := range d.Values {
+		if !slices.Contains(literals, v.Value) {
+			literals = append(literals, v.Value)
+		}
+	}
+	union := strings.Join(literals, " | ")
+
+	if len(d.Members) == 0 {
+		w.outLine(fmt.Sprintf("%stype %s = %s", w.exportKeyword(), d.Name, union))
+	} else {
+		w.outLine(fmt.Sprintf("%stype %s = (%s) & {", w.exportKeyword(), d.Name, union))
+		w.doIndent()
+		for _, m := range d.Members {
+			w.writeMember(m)
+		}
+		w.doDeIndent()
+		w.outLine("}")
+	}
+
+	if !d.ValuesObject {
+		return
+	}
+	if w.inNamespace {
+		w.outLine(fmt.Sprintf("%sconst %s: {", w.exportKeyword(), d.Name))
+	} else {
+		w.outLine(fmt.Sprintf("%sdeclare const %s: {", w.exportKeyword(), d.Name))
+	}
+	w.doIndent()
+	for _, v := range d.Values {
+		if v.Name == "" {
+			continue
+		}
+		w.writeDoc(v.Doc)
+		w.outLine(fmt.Sprintf("readonly %s: %s", quotePropertyName(v.Name), v.Value))
+	}
+	w.doDeIndent()
+	w.outLine("}")
+}
+
+func (w *typingsWriter) writeMember(m *tsMember) {
+	if m.Comment != "" {
+		w.outLine("// " + m.Comment)
+		return
+	}
+	doc := m.Doc
+	if m.Throws || m.Type != nil && m.Type.Kind == tsFunc && m.Type.Throws {
+		doc = strings.TrimSpace(doc) + "\n\n" + throwsDoc
+	}
+	w.writeDoc(doc)
+
+	name := quotePropertyName(m.Name)
+	if m.IsMethod {
+		w.outLine(fmt.Sprintf("%s(%s): %s", name, w.paramsString(m.Params), w.resultString(m.Result)))
+		return
+	}
+	if m.Optional {
+		name += "?"
+	}
+	w.outLine(fmt.Sprintf("%s: %s", name, w.typeString(m.Type)))
+}
+
+func (w *typingsWriter) paramsString(params []*tsParam) string {
+	result := []string{}
+	for _, p := range params {
+		if p.Variadic {
+			result = append(result, fmt.Sprintf("...%s: %s", p.Name, w.typeString(p.Type)))
+		} else {
+			result = append(result, fmt.Sprintf("%s: %s", p.Name, w.typeString(p.Type)))
+		}
+	}
+	return strings.Join(result, ", ")
+}
+
+func (w *typingsWriter) resultString(t *tsType) string {
+	if t == nil {
+		return "void"
+	}
+	return w.typeString(t)
+}
+
+func (w *typingsWriter) writeDoc(doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	lines := strings.Split(doc, "\n")
+	if len(lines) == 1 {
+		w.outLine("/** " + escapeDoc(lines[0]) + " */")
+		return
+	}
+	w.outLine("/**")
+	for _, l := range lines {
+		w.outLine(strings.TrimRight(" * "+escapeDoc(l), " "))
+	}
+	w.outLine(" */")
+}
+
+func escapeDoc(v string) string {
+	return strings.ReplaceAll(v, "*/", "*\\/")
+}
+
+func (w *typingsWriter) typeString(t *tsType) string {
+	switch t.Kind {
+	case tsRef:
+		if len(t.Args) == 0 {
+			return t.Name
+		}
+		args := []string{}
+		for _, a := range t.Args {
+			args = append(args, w.typeString(a))
+		}
+		return fmt.Sprintf("%s<%s>", t.Name, strings.Join(args, ", "))
+	case tsNullable:
+		if t.Args[0].Kind == tsFunc {
+			return fmt.Sprintf("null | (%s)", w.typeString(t.Args[0]))
+		}
+		return "null | " + w.typeString(t.Args[0])
+	case tsFunc:
+		return fmt.Sprintf("(%s) => %s", w.paramsString(t.Params), w.resultString(t.Result))
+	case tsArray:
+		if t.Args[0].Kind == tsNullable || t.Args[0].Kind == tsFunc || t.Args[0].Kind == tsUnion {
+			return fmt.Sprintf("(%s)[]", w.typeString(t.Args[0]))
+		}
+		return w.typeString(t.Args[0]) + "[]"
+	case tsTuple:
+		elems := []string{}
+		for _, e := range t.Args {
+			elems = append(elems, w.typeString(e))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case tsRecord:
+		return fmt.Sprintf("Record<%s, %s>", w.typeString(t.Args[0]), w.typeString(t.Args[1]))
+	case tsMapped:
+		return fmt.Sprintf("{ [K in %s]?: %s }", w.typeString(t.Args[0]), w.typeString(t.Args[1]))
+	case tsUnion:
+		elems := []string{}
+		for _, e := range t.Args {
+			elems = append(elems, w.typeString(e))
+		}
+		return strings.Join(elems, " | ")
+	case tsObject:
+		sb := &strings.Builder{}
+		nested := typingsWriter{out: sb, indent: w.indent + 1, opts: w.opts}
+		for _, m := range t.Members {
+			nested.writeMember(m)
+		}
+		result := "{\n" + sb.String() + w.indentString() + "}"
+		for _, e := range t.Extends {
+			result += " & " + w.typeString(e)
+		}
+		return result
+	default:
+		return t.Name
+	}
+}